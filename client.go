@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/ThijsKoot/openlineage-go/pkg/metrics"
 	"github.com/ThijsKoot/openlineage-go/pkg/transport"
+	"github.com/google/uuid"
 )
 
 var DefaultClient, _ = NewClient(ClientConfig{
@@ -17,33 +20,135 @@ var DefaultClient, _ = NewClient(ClientConfig{
 	},
 })
 
-func NewClient(cfg ClientConfig) (*Client, error) {
+// NewClient creates a Client from cfg, which is checked with [ClientConfig.Validate] unless the client is disabled.
+func NewClient(cfg ClientConfig, opts ...ClientOption) (*Client, error) {
 	if cfg.Disabled || os.Getenv("OPENLINEAGE_DISABLED") != "" {
-		return &Client{
-			disabled: true,
-		}, nil
+		return NewClientWithTransport(cfg, nil, opts...), nil
 	}
 
-	transport, err := transport.New(cfg.Transport)
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	c := NewClientWithTransport(cfg, nil, opts...)
+
+	t, err := transport.New(cfg.Transport, transport.WithName(c.transportName), transport.WithMetrics(c.metrics))
 	if err != nil {
 		return nil, fmt.Errorf("create transport: %w", err)
 	}
 
+	c.transport = t
+
+	for name, tc := range cfg.Transports {
+		t, err := transport.New(tc, transport.WithName(name), transport.WithMetrics(c.metrics))
+		if err != nil {
+			return nil, fmt.Errorf("create transport %s: %w", name, err)
+		}
+
+		c.routes[name] = t
+	}
+
+	return c, nil
+}
+
+// NewClientWithTransport creates a Client that emits events using t.
+// The transport configurations in cfg are ignored, including cfg.Transports.
+// Its rules are applied, but emitting an event that a rule routes to a named transport returns an error.
+func NewClientWithTransport(cfg ClientConfig, t transport.Transport, opts ...ClientOption) *Client {
 	namespace := cfg.Namespace
 	if cfg.Namespace == "" {
 		namespace = "default"
 	}
 
-	return &Client{
-		transport: transport,
-		Namespace: namespace,
-	}, nil
+	transportName := string(cfg.Transport.Type)
+	if transportName == "" {
+		transportName = "custom"
+	}
+
+	c := &Client{
+		disabled:      cfg.Disabled || os.Getenv("OPENLINEAGE_DISABLED") != "",
+		transport:     t,
+		transportName: transportName,
+		clock:         systemClock,
+		idGenerator:   uuidV7Generator,
+		metrics:       metrics.Noop{},
+		rules:         compileRules(cfg.Rules),
+		routes:        map[string]transport.Transport{},
+		Namespace:     namespace,
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
+}
+
+// ClientOption configures a [Client].
+type ClientOption func(*Client)
+
+// WithClock sets the Clock used to timestamp events created by the Client. Defaults to the system clock.
+func WithClock(clock Clock) ClientOption {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// WithIDGenerator sets the IDGenerator used for new runs. Defaults to generating UUIDv7s.
+func WithIDGenerator(generator IDGenerator) ClientOption {
+	return func(c *Client) {
+		c.idGenerator = generator
+	}
+}
+
+// WithMetrics reports measurements of emitted events to r, see package metrics.
+// It applies to the transports created by [NewClient] as well.
+func WithMetrics(r metrics.Recorder) ClientOption {
+	return func(c *Client) {
+		c.metrics = r
+	}
 }
 
 type Client struct {
-	disabled  bool
-	transport transport.Transport
-	Namespace string
+	disabled    bool
+	transport   transport.Transport
+	clock       Clock
+	idGenerator IDGenerator
+	metrics     metrics.Recorder
+	Namespace   string
+
+	// transportName labels measurements, it is the configured transport type
+	transportName string
+
+	// rules are applied to events in Emit, routing them to the transports in routes by name
+	rules  []rule
+	routes map[string]transport.Transport
+}
+
+// Now returns the current time according to the Client's [Clock].
+func (olc *Client) Now() time.Time {
+	if olc == nil || olc.clock == nil {
+		return systemClock.Now()
+	}
+
+	return olc.clock.Now()
+}
+
+// NewRunID returns a new run ID from the Client's [IDGenerator].
+func (olc *Client) NewRunID() uuid.UUID {
+	if olc == nil || olc.idGenerator == nil {
+		return uuidV7Generator.NewID()
+	}
+
+	return olc.idGenerator.NewID()
+}
+
+func (olc *Client) newBaseEvent() BaseEvent {
+	return BaseEvent{
+		Producer:  producer,
+		SchemaURL: schemaURL,
+		EventTime: olc.Now().Format(time.RFC3339),
+	}
 }
 
 type Emittable interface {
@@ -52,8 +157,59 @@ type Emittable interface {
 
 func (olc *Client) Emit(ctx context.Context, event Emittable) error {
 	if olc.disabled {
+		olc.metrics.Dropped(ctx, metrics.DroppedDisabled)
 		return nil
 	}
 
-	return olc.transport.Emit(ctx, event.AsEmittable())
+	e := event.AsEmittable()
+	t, transportName := olc.transport, olc.transportName
+
+	if r := matchRule(olc.rules, e); r != nil {
+		if r.Disabled {
+			olc.metrics.Dropped(ctx, metrics.DroppedRule)
+			return nil
+		}
+
+		if r.Transport != "" {
+			rt, ok := olc.routes[r.Transport]
+			if !ok {
+				olc.metrics.Failed(ctx, r.Transport, e.kind(), metrics.FailedNoTransport, 0)
+
+				return fmt.Errorf("rule routes event to transport %q, which the client was not created with", r.Transport)
+			}
+
+			t, transportName = rt, r.Transport
+		}
+
+		if r.SetNamespace != "" && e.Job != nil {
+			// copy the job, so that the caller's event is left unchanged
+			job := *e.Job
+			job.Namespace = r.SetNamespace
+			e.Job = &job
+		}
+	}
+
+	e.Run = setParentNamespaces(olc.rules, e.Run)
+
+	start := time.Now()
+	err := t.Emit(ctx, e)
+	d := time.Since(start)
+
+	if err != nil {
+		olc.metrics.Failed(ctx, transportName, e.kind(), failureReason(err), d)
+		return err
+	}
+
+	olc.metrics.Emitted(ctx, transportName, e.kind(), d)
+
+	return nil
+}
+
+// Metrics returns the Recorder set with [WithMetrics], or one that discards measurements.
+func (olc *Client) Metrics() metrics.Recorder {
+	if olc == nil || olc.metrics == nil {
+		return metrics.Noop{}
+	}
+
+	return olc.metrics
 }
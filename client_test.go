@@ -0,0 +1,49 @@
+package openlineage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/google/uuid"
+)
+
+func Test_Client_Clock(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	id := uuid.MustParse("0190e8b5-5f4e-7a4c-a1a4-16d4ba5c1b8c")
+
+	client := openlineage.NewClientWithTransport(
+		openlineage.ClientConfig{Namespace: "ns"},
+		nil,
+		openlineage.WithClock(openlineage.ClockFunc(func() time.Time { return now })),
+		openlineage.WithIDGenerator(openlineage.IDGeneratorFunc(func() uuid.UUID { return id })),
+	)
+
+	want := "2024-07-01T12:00:00Z"
+
+	runEvent := client.NewRunEvent(openlineage.EventTypeStart, client.NewRunID(), "job")
+	if runEvent.EventTime != want {
+		t.Errorf("run event time = %s, want %s", runEvent.EventTime, want)
+	}
+
+	if runEvent.Run.RunID != id.String() {
+		t.Errorf("run ID = %s, want %s", runEvent.Run.RunID, id)
+	}
+
+	if runEvent.Job.Namespace != "ns" {
+		t.Errorf("job namespace = %s, want %s", runEvent.Job.Namespace, "ns")
+	}
+
+	jobEvent := client.NewJobEvent("job")
+	if jobEvent.EventTime != want {
+		t.Errorf("job event time = %s, want %s", jobEvent.EventTime, want)
+	}
+
+	if jobEvent.Job.Namespace != "ns" {
+		t.Errorf("job event namespace = %s, want %s", jobEvent.Job.Namespace, "ns")
+	}
+
+	if e := client.NewDatasetEvent("dataset", "ns"); e.EventTime != want {
+		t.Errorf("dataset event time = %s, want %s", e.EventTime, want)
+	}
+}
@@ -0,0 +1,41 @@
+package openlineage
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Clock provides the time at which events occur.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to a [Clock].
+type ClockFunc func() time.Time
+
+// Now implements Clock.
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// IDGenerator generates IDs for new runs.
+type IDGenerator interface {
+	NewID() uuid.UUID
+}
+
+// IDGeneratorFunc adapts a function to an [IDGenerator].
+type IDGeneratorFunc func() uuid.UUID
+
+// NewID implements IDGenerator.
+func (f IDGeneratorFunc) NewID() uuid.UUID {
+	return f()
+}
+
+var (
+	systemClock = ClockFunc(time.Now)
+
+	uuidV7Generator = IDGeneratorFunc(func() uuid.UUID {
+		return uuid.Must(uuid.NewV7())
+	})
+)
@@ -0,0 +1,420 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+	"github.com/ThijsKoot/openlineage-go/pkg/transport"
+	"github.com/google/uuid"
+)
+
+// runIDEnv is read by complete, fail and event when no run ID is supplied.
+const runIDEnv = "OPENLINEAGE_RUN_ID"
+
+var _ flag.Value = (*datasetsFlag)(nil)
+
+// datasetsFlag is a repeatable flag of datasets in the form namespace:name.
+type datasetsFlag []openlineage.Dataset
+
+func (d *datasetsFlag) String() string {
+	if d == nil {
+		return ""
+	}
+
+	names := make([]string, len(*d))
+	for i, ds := range *d {
+		names[i] = ds.Namespace + ":" + ds.Name
+	}
+
+	return strings.Join(names, ",")
+}
+
+func (d *datasetsFlag) Set(value string) error {
+	ds, err := parseDataset(value)
+	if err != nil {
+		return err
+	}
+
+	*d = append(*d, ds)
+
+	return nil
+}
+
+// parseDataset parses a dataset in the form namespace:name.
+// The value is split at the last colon, as namespaces are often URIs such as postgres://host:5432.
+func parseDataset(value string) (openlineage.Dataset, error) {
+	i := strings.LastIndex(value, ":")
+	if i <= 0 || i == len(value)-1 {
+		return openlineage.Dataset{}, fmt.Errorf("invalid dataset %q, expected namespace:name", value)
+	}
+
+	return openlineage.Dataset{Namespace: value[:i], Name: value[i+1:]}, nil
+}
+
+// eventFlags are the flags shared by the commands emitting run and job events.
+type eventFlags struct {
+	job       string
+	namespace string
+	runID     string
+	inputs    datasetsFlag
+	outputs   datasetsFlag
+	runFacets string
+	jobFacets string
+}
+
+func (f *eventFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.job, "job", "", "name of the job (required)")
+	fs.StringVar(&f.namespace, "namespace", "", "namespace of the job (default: the configured namespace)")
+	fs.Var(&f.inputs, "input", "input dataset as namespace:name, can be repeated")
+	fs.Var(&f.outputs, "output", "output dataset as namespace:name, can be repeated")
+	fs.StringVar(&f.runFacets, "run-facets", "", "JSON file containing an object of run facets keyed by name")
+	fs.StringVar(&f.jobFacets, "job-facets", "", "JSON file containing an object of job facets keyed by name")
+}
+
+func (f *eventFlags) registerRunID(fs *flag.FlagSet, newRun bool) {
+	usage := "ID of the run (default: a new ID)"
+	if !newRun {
+		usage = "ID of the run (default: $" + runIDEnv + ")"
+	}
+
+	fs.StringVar(&f.runID, "run-id", "", usage)
+}
+
+// resolveRunID returns the run ID from the flags.
+// Without a run ID, one is generated by client for a new run, or read from the environment otherwise.
+func (f *eventFlags) resolveRunID(client *openlineage.Client, newRun bool) (uuid.UUID, error) {
+	runID := f.runID
+	if runID == "" && !newRun {
+		runID = os.Getenv(runIDEnv)
+	}
+
+	if runID == "" {
+		if !newRun {
+			return uuid.Nil, usageError{err: fmt.Errorf("-run-id is required when $%s is not set", runIDEnv)}
+		}
+
+		return client.NewRunID(), nil
+	}
+
+	id, err := uuid.Parse(runID)
+	if err != nil {
+		return uuid.Nil, usageError{err: fmt.Errorf("invalid run ID %q: %w", runID, err)}
+	}
+
+	return id, nil
+}
+
+// newRun creates the Run the flags describe.
+// A run propagated through the environment, for example by "openlineage exec", is set as its parent.
+func (f *eventFlags) newRun(ctx context.Context, client *openlineage.Client, runID uuid.UUID) (context.Context, run.Run) {
+	return run.NewClient(client).NewRun(ctx, f.job, run.WithRunID(runID))
+}
+
+// newRunEvent builds an event for r with the datasets and facets from the flags.
+func (f *eventFlags) newRunEvent(r run.Run, eventType openlineage.EventType) (*openlineage.RunEvent, error) {
+	event := r.NewEvent(eventType).
+		WithInputs(inputElements(f.inputs)...).
+		WithOutputs(outputElements(f.outputs)...)
+
+	if f.runFacets != "" {
+		if event.Run.Facets == nil {
+			event.Run.Facets = &facets.RunFacets{}
+		}
+
+		if err := readFacets(f.runFacets, event.Run.Facets); err != nil {
+			return nil, err
+		}
+	}
+
+	if f.jobFacets != "" {
+		if event.Job.Facets == nil {
+			event.Job.Facets = &facets.JobFacets{}
+		}
+
+		if err := readFacets(f.jobFacets, event.Job.Facets); err != nil {
+			return nil, err
+		}
+	}
+
+	return event, nil
+}
+
+func (f *eventFlags) validate() error {
+	if f.job == "" {
+		return usageError{err: errors.New("-job is required")}
+	}
+
+	return nil
+}
+
+func inputElements(datasets []openlineage.Dataset) []openlineage.InputElement {
+	inputs := make([]openlineage.InputElement, len(datasets))
+	for i, ds := range datasets {
+		inputs[i] = openlineage.NewInputElement(ds.Name, ds.Namespace)
+	}
+
+	return inputs
+}
+
+func outputElements(datasets []openlineage.Dataset) []openlineage.OutputElement {
+	outputs := make([]openlineage.OutputElement, len(datasets))
+	for i, ds := range datasets {
+		outputs[i] = openlineage.NewOutputElement(ds.Name, ds.Namespace)
+	}
+
+	return outputs
+}
+
+// readFacets decodes the JSON object in file into v, which points to one of the facets structs.
+// Facets that are not part of the specification are kept as custom facets.
+func readFacets(file string, v any) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("read facets: %w", err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decode facets in %s: %w", file, err)
+	}
+
+	return nil
+}
+
+// newClient creates a client from the environment, overriding its namespace if namespace is not empty.
+// Console transports without a target write to stderr, so that stdout only carries the output of commands,
+// such as the run ID printed by start.
+func (a *app) newClient(namespace string) (*openlineage.Client, error) {
+	cfg, err := openlineage.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return a.newClientFromConfig(cfg, namespace)
+}
+
+// newClientFromConfig creates a client from cfg like newClient.
+func (a *app) newClientFromConfig(cfg openlineage.ClientConfig, namespace string) (*openlineage.Client, error) {
+	if namespace != "" {
+		cfg.Namespace = namespace
+	}
+
+	cfg.Transport = a.consoleToStderr(cfg.Transport)
+	for name, t := range cfg.Transports {
+		cfg.Transports[name] = a.consoleToStderr(t)
+	}
+
+	return openlineage.NewClient(cfg)
+}
+
+// consoleToStderr directs a console transport without a target to stderr.
+func (a *app) consoleToStderr(t transport.Config) transport.Config {
+	if t.Type != transport.TransportTypeConsole {
+		return t
+	}
+
+	var console transport.ConsoleConfig
+	if t.Console != nil {
+		console = *t.Console
+	}
+
+	if console.Target == "" && console.Writer == nil {
+		console.Writer = a.stderr
+	}
+
+	t.Console = &console
+
+	return t
+}
+
+func runStart(ctx context.Context, a *app, args []string) error {
+	var f eventFlags
+
+	fs := a.newFlagSet("start", "-job <name> [flags]")
+	f.register(fs)
+	f.registerRunID(fs, true)
+	fs.Usage = withFooter(fs.Usage, a, "The run ID is printed to stdout, e.g. for use as: export "+runIDEnv+"=$(openlineage start -job my-job)")
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	return a.emitRun(ctx, &f, openlineage.EventTypeStart, true, nil)
+}
+
+func runComplete(ctx context.Context, a *app, args []string) error {
+	var f eventFlags
+
+	fs := a.newFlagSet("complete", "-job <name> [flags]")
+	f.register(fs)
+	f.registerRunID(fs, false)
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	return a.emitRun(ctx, &f, openlineage.EventTypeComplete, false, nil)
+}
+
+func runFail(ctx context.Context, a *app, args []string) error {
+	var (
+		f       eventFlags
+		message string
+	)
+
+	fs := a.newFlagSet("fail", "-job <name> [flags]")
+	f.register(fs)
+	f.registerRunID(fs, false)
+	fs.StringVar(&message, "message", "", "error message to attach as an errorMessage facet")
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	var runFacets []facets.RunFacet
+	if message != "" {
+		runFacets = append(runFacets, facets.NewErrorMessage(message, "shell"))
+	}
+
+	return a.emitRun(ctx, &f, openlineage.EventTypeFail, false, runFacets)
+}
+
+func runEvent(ctx context.Context, a *app, args []string) error {
+	var (
+		f         eventFlags
+		eventType string
+	)
+
+	fs := a.newFlagSet("event", "-job <name> [-type <type>] [flags]")
+	f.register(fs)
+	f.registerRunID(fs, false)
+	fs.StringVar(&eventType, "type", "", "type of the run event: START, RUNNING, COMPLETE, FAIL, ABORT or OTHER.\nWithout a type, a job event is emitted")
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	if eventType != "" {
+		return a.emitRun(ctx, &f, openlineage.EventType(strings.ToUpper(eventType)), false, nil)
+	}
+
+	if err := f.validate(); err != nil {
+		return err
+	}
+
+	if f.runFacets != "" {
+		return usageError{err: errors.New("-run-facets requires -type")}
+	}
+
+	client, err := a.newClient(f.namespace)
+	if err != nil {
+		return err
+	}
+
+	event := client.NewJobEvent(f.job).
+		WithInputs(inputElements(f.inputs)...).
+		WithOutputs(outputElements(f.outputs)...)
+
+	if f.jobFacets != "" {
+		event.Job.Facets = &facets.JobFacets{}
+		if err := readFacets(f.jobFacets, event.Job.Facets); err != nil {
+			return err
+		}
+	}
+
+	return client.Emit(ctx, event)
+}
+
+// emitRun emits a run event of eventType built from f.
+// For a new run, the run ID is written to stdout.
+func (a *app) emitRun(ctx context.Context, f *eventFlags, eventType openlineage.EventType, newRun bool, runFacets []facets.RunFacet) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+
+	client, err := a.newClient(f.namespace)
+	if err != nil {
+		return err
+	}
+
+	runID, err := f.resolveRunID(client, newRun)
+	if err != nil {
+		return err
+	}
+
+	_, r := f.newRun(ctx, client, runID)
+
+	event, err := f.newRunEvent(r, eventType)
+	if err != nil {
+		return err
+	}
+
+	event = event.WithRunFacets(runFacets...)
+	if err := openlineage.ValidateEvent(event.AsEmittable()); err != nil {
+		return usageError{err: err}
+	}
+
+	if err := client.Emit(ctx, event); err != nil {
+		return fmt.Errorf("emit %s event: %w", eventType, err)
+	}
+
+	if newRun {
+		fmt.Fprintln(a.stdout, runID)
+	}
+
+	return nil
+}
+
+func runDataset(ctx context.Context, a *app, args []string) error {
+	var (
+		dataset    string
+		facetsFile string
+	)
+
+	fs := a.newFlagSet("dataset", "-dataset <namespace:name> [flags]")
+	fs.StringVar(&dataset, "dataset", "", "dataset as namespace:name (required)")
+	fs.StringVar(&facetsFile, "facets", "", "JSON file containing an object of dataset facets keyed by name")
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	if dataset == "" {
+		return usageError{err: errors.New("-dataset is required")}
+	}
+
+	ds, err := parseDataset(dataset)
+	if err != nil {
+		return usageError{err: err}
+	}
+
+	client, err := a.newClient("")
+	if err != nil {
+		return err
+	}
+
+	event := client.NewDatasetEvent(ds.Name, ds.Namespace)
+	if facetsFile != "" {
+		event.Dataset.Facets = &facets.DatasetFacets{}
+		if err := readFacets(facetsFile, event.Dataset.Facets); err != nil {
+			return err
+		}
+	}
+
+	return client.Emit(ctx, &event)
+}
+
+// withFooter returns a usage function that calls usage and prints footer.
+func withFooter(usage func(), a *app, footer string) func() {
+	return func() {
+		usage()
+		fmt.Fprintf(a.stderr, "\n%s\n", footer)
+	}
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+)
+
+// forwardedSignals are passed on to the command run by exec.
+// On unix, the command runs in its own process group, so it receives them once even if they were sent to the whole group.
+// The run is aborted if the command is terminated by the signal; if it handles the signal, its exit code determines the event.
+var forwardedSignals = []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGHUP}
+
+// exitError makes the CLI exit with code, without printing an error.
+type exitError struct {
+	code int
+}
+
+func (e exitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+func runExec(ctx context.Context, a *app, args []string) error {
+	var (
+		f         eventFlags
+		tailLines int
+	)
+
+	fs := a.newFlagSet("exec", "-job <name> [flags] -- <command> [args...]")
+	f.register(fs)
+	f.registerRunID(fs, true)
+	fs.IntVar(&tailLines, "stderr-lines", 20, "number of trailing lines of stderr to attach to the FAIL event")
+	fs.Usage = withFooter(fs.Usage, a, "The run is propagated to the command through OPENLINEAGE_PARENT_* environment variables,\n"+
+		"so runs it creates with this module or the openlineage CLI become children of the run.\n"+
+		"The exit code of the command is returned.")
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return usageError{err: errors.New("no command specified")}
+	}
+
+	if err := f.validate(); err != nil {
+		return err
+	}
+
+	client, err := a.newClient(f.namespace)
+	if err != nil {
+		return err
+	}
+
+	runID, err := f.resolveRunID(client, true)
+	if err != nil {
+		return err
+	}
+
+	ctx, r := f.newRun(ctx, client, runID)
+
+	env := run.EnvCarrier(os.Environ())
+	run.Inject(ctx, &env)
+
+	stderrTail := newTailWriter(tailLines)
+
+	cmd := exec.Command(fs.Arg(0), fs.Args()[1:]...)
+	cmd.Env = env
+	cmd.Stdin = a.stdin
+	cmd.Stdout = a.stdout
+	cmd.Stderr = io.MultiWriter(a.stderr, stderrTail)
+	isolateProcessGroup(cmd)
+
+	a.emitExecEvent(ctx, client, &f, r, openlineage.EventTypeStart)
+
+	// listen before starting the command, so no signal is missed
+	signals := a.signals
+	if signals == nil {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, forwardedSignals...)
+		defer signal.Stop(ch)
+
+		signals = ch
+	}
+
+	if err := cmd.Start(); err != nil {
+		a.emitExecEvent(ctx, client, &f, r, openlineage.EventTypeFail, facets.NewErrorMessage(err.Error(), "shell"))
+		return fmt.Errorf("start command: %w", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	var (
+		waitErr   error
+		forwarded []os.Signal
+	)
+
+wait:
+	for {
+		select {
+		case sig := <-signals:
+			forwarded = append(forwarded, sig)
+			_ = cmd.Process.Signal(sig)
+		case waitErr = <-done:
+			break wait
+		}
+	}
+
+	var exitErr *exec.ExitError
+	if waitErr != nil && !errors.As(waitErr, &exitErr) {
+		a.emitExecEvent(ctx, client, &f, r, openlineage.EventTypeFail, facets.NewErrorMessage(waitErr.Error(), "shell"))
+		return fmt.Errorf("wait for command: %w", waitErr)
+	}
+
+	switch {
+	case waitErr == nil:
+		a.emitExecEvent(ctx, client, &f, r, openlineage.EventTypeComplete)
+		return nil
+	case slices.Contains(forwarded, terminatingSignal(cmd.ProcessState)):
+		a.emitExecEvent(ctx, client, &f, r, openlineage.EventTypeAbort)
+	default:
+		message := stderrTail.String()
+		if message == "" {
+			message = waitErr.Error()
+		}
+
+		a.emitExecEvent(ctx, client, &f, r, openlineage.EventTypeFail, facets.NewErrorMessage(message, "shell"))
+	}
+
+	return exitError{code: exitCode(cmd.ProcessState)}
+}
+
+// emitExecEvent emits an event for the run of exec.
+// Failures are reported on stderr, but do not stop the command.
+func (a *app) emitExecEvent(ctx context.Context, client *openlineage.Client, f *eventFlags, r run.Run, eventType openlineage.EventType, runFacets ...facets.RunFacet) {
+	event, err := f.newRunEvent(r, eventType)
+	if err == nil {
+		err = client.Emit(ctx, event.WithRunFacets(runFacets...))
+	}
+
+	if err != nil {
+		fmt.Fprintf(a.stderr, "openlineage: emit %s event: %s\n", eventType, err)
+	}
+}
+
+// exitCode returns the exit code of a process, following the shell convention of 128+n for processes killed by signal n.
+func exitCode(state *os.ProcessState) int {
+	if code := state.ExitCode(); code >= 0 {
+		return code
+	}
+
+	if ws, ok := state.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		return 128 + int(ws.Signal())
+	}
+
+	return 1
+}
+
+// terminatingSignal returns the signal that terminated a process, or nil if it exited.
+func terminatingSignal(state *os.ProcessState) os.Signal {
+	if ws, ok := state.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		return ws.Signal()
+	}
+
+	return nil
+}
+
+// maxTailLineLength is the number of bytes kept of lines that are not terminated, such as binary output.
+const maxTailLineLength = 4096
+
+// tailWriter keeps the last lines written to it.
+type tailWriter struct {
+	mu    sync.Mutex
+	limit int
+	lines []string
+
+	// partial is the last line, if it has not been terminated yet
+	partial []byte
+}
+
+func newTailWriter(lines int) *tailWriter {
+	return &tailWriter{limit: max(lines, 0)}
+}
+
+// Write implements io.Writer.
+func (t *tailWriter) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.partial = append(t.partial, p...)
+	for {
+		i := bytes.IndexByte(t.partial, '\n')
+		if i < 0 {
+			break
+		}
+
+		t.lines = append(t.lines, string(t.partial[:i]))
+		t.partial = t.partial[i+1:]
+	}
+
+	if len(t.partial) > maxTailLineLength {
+		t.partial = t.partial[len(t.partial)-maxTailLineLength:]
+	}
+
+	if len(t.lines) > t.limit {
+		t.lines = t.lines[len(t.lines)-t.limit:]
+	}
+
+	return len(p), nil
+}
+
+// String returns the kept lines, including an unterminated last line.
+func (t *tailWriter) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	lines := t.lines
+	if len(t.partial) > 0 {
+		lines = append(lines[:len(lines):len(lines)], string(t.partial))
+	}
+
+	if len(lines) > t.limit {
+		lines = lines[len(lines)-t.limit:]
+	}
+
+	return strings.Join(lines, "\n")
+}
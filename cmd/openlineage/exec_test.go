@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/oltest"
+	"github.com/google/uuid"
+)
+
+// helperEnv selects the behaviour of the test binary when it is run as a command by exec.
+const helperEnv = "OPENLINEAGE_TEST_HELPER"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(helperEnv) {
+	case "":
+		os.Exit(m.Run())
+	case "nested":
+		a := &app{stdout: os.Stdout, stderr: os.Stderr}
+		if err := a.run(context.Background(), []string{"start", "-job", "child"}); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	case "fail":
+		for i := 1; i <= 30; i++ {
+			fmt.Fprintf(os.Stderr, "line %d\n", i)
+		}
+		fmt.Fprint(os.Stderr, "unterminated")
+		os.Exit(3)
+	case "sleep":
+		time.Sleep(10 * time.Second)
+	case "pgid":
+		fmt.Println(processGroup())
+	case "trap", "trap-fail":
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt)
+		fmt.Println("ready")
+
+		select {
+		case <-signals:
+		case <-time.After(10 * time.Second):
+		}
+
+		if os.Getenv(helperEnv) == "trap-fail" {
+			os.Exit(2)
+		}
+	}
+
+	os.Exit(0)
+}
+
+func Test_Exec_Complete(t *testing.T) {
+	srv := newServer(t)
+	t.Setenv(helperEnv, "nested")
+
+	if _, err := execute(t, "exec", "-job", "parent", "--", os.Args[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	events := srv.Events()
+	parent := oltest.Select(events, oltest.ByJob("cli", "parent"))
+	if len(parent) != 2 || *parent[0].EventType != openlineage.EventTypeStart || *parent[1].EventType != openlineage.EventTypeComplete {
+		t.Fatalf("expected START and COMPLETE for parent, got %+v", parent)
+	}
+
+	child := oltest.Select(events, oltest.ByJob("cli", "child"))
+	if len(child) != 1 {
+		t.Fatalf("expected one event for child, got %d", len(child))
+	}
+
+	facets := child[0].Run.Facets
+	if facets == nil || facets.Parent == nil || facets.Parent.Run.RunID != parent[0].Run.RunID {
+		t.Errorf("child run is not a child of %s: %+v", parent[0].Run.RunID, facets)
+	}
+}
+
+func Test_Exec_Fail(t *testing.T) {
+	srv := newServer(t)
+	t.Setenv(helperEnv, "fail")
+
+	_, err := execute(t, "exec", "-job", "load", "-stderr-lines", "3", "--", os.Args[0])
+
+	var exit exitError
+	if !errors.As(err, &exit) || exit.code != 3 {
+		t.Fatalf("expected exit code 3, got %v", err)
+	}
+
+	events := srv.Events()
+	if len(events) != 2 || *events[1].EventType != openlineage.EventTypeFail {
+		t.Fatalf("expected START and FAIL, got %+v", events)
+	}
+
+	errorMessage := events[1].Run.Facets.ErrorMessage
+	if want := "line 29\nline 30\nunterminated"; errorMessage == nil || errorMessage.Message != want {
+		t.Errorf("expected error message %q, got %+v", want, errorMessage)
+	}
+}
+
+func Test_Exec_Abort(t *testing.T) {
+	srv := newServer(t)
+	t.Setenv(helperEnv, "sleep")
+
+	signals := make(chan os.Signal, 1)
+	signals <- os.Interrupt
+
+	var stdout, stderr strings.Builder
+	a := &app{stdout: &stdout, stderr: &stderr, signals: signals}
+
+	err := a.run(context.Background(), []string{"exec", "-job", "load", "--", os.Args[0]})
+
+	var exit exitError
+	if !errors.As(err, &exit) || exit.code == 0 {
+		t.Fatalf("expected a non-zero exit code, got %v", err)
+	}
+
+	events := srv.Events()
+	if len(events) != 2 || *events[1].EventType != openlineage.EventTypeAbort {
+		t.Fatalf("expected START and ABORT, got %+v", events)
+	}
+}
+
+// signalOnWrite sends a signal once the command writes to stdout, so that it is not sent before the command handles it.
+type signalOnWrite struct {
+	signals chan<- os.Signal
+	once    sync.Once
+}
+
+func (w *signalOnWrite) Write(p []byte) (int, error) {
+	w.once.Do(func() { w.signals <- os.Interrupt })
+	return len(p), nil
+}
+
+func Test_Exec_SignalHandled(t *testing.T) {
+	tests := []struct {
+		helper    string
+		wantCode  int
+		wantEvent openlineage.EventType
+	}{
+		{helper: "trap", wantEvent: openlineage.EventTypeComplete},
+		{helper: "trap-fail", wantCode: 2, wantEvent: openlineage.EventTypeFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.helper, func(t *testing.T) {
+			srv := newServer(t)
+			t.Setenv(helperEnv, tt.helper)
+
+			signals := make(chan os.Signal, 1)
+			var stderr strings.Builder
+			a := &app{stdout: &signalOnWrite{signals: signals}, stderr: &stderr, signals: signals}
+
+			err := a.run(context.Background(), []string{"exec", "-job", "load", "--", os.Args[0]})
+
+			var exit exitError
+			switch {
+			case tt.wantCode == 0 && err != nil:
+				t.Fatalf("expected success, got %v", err)
+			case tt.wantCode != 0 && (!errors.As(err, &exit) || exit.code != tt.wantCode):
+				t.Fatalf("expected exit code %d, got %v", tt.wantCode, err)
+			}
+
+			events := srv.Events()
+			if len(events) != 2 || *events[1].EventType != tt.wantEvent {
+				t.Fatalf("expected START and %s, got %+v", tt.wantEvent, events)
+			}
+		})
+	}
+}
+
+func Test_Exec_CommandNotFound(t *testing.T) {
+	srv := newServer(t)
+
+	if _, err := execute(t, "exec", "-job", "load", "--", "./does-not-exist"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	events := srv.Events()
+	if len(events) != 2 || *events[1].EventType != openlineage.EventTypeFail {
+		t.Fatalf("expected START and FAIL, got %+v", events)
+	}
+}
+
+func Test_Exec_Console(t *testing.T) {
+	t.Setenv("OPENLINEAGE_TRANSPORT", "console")
+	t.Setenv("OPENLINEAGE_NAMESPACE", "cli")
+	t.Setenv(helperEnv, "nested")
+
+	var stdout, stderr strings.Builder
+	a := &app{stdout: &stdout, stderr: &stderr}
+
+	if err := a.run(context.Background(), []string{"exec", "-job", "parent", "--", os.Args[0]}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the nested start prints the run ID of the child, and nothing else may be written to stdout
+	if out := strings.TrimSpace(stdout.String()); uuid.Validate(out) != nil {
+		t.Fatalf("stdout contains more than the output of the command: %q", out)
+	}
+
+	var types []openlineage.EventType
+	for _, line := range strings.Split(strings.TrimSpace(stderr.String()), "\n") {
+		var event openlineage.Event
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("console transport wrote %q to stderr: %s", line, err)
+		}
+
+		types = append(types, *event.EventType)
+	}
+
+	want := []openlineage.EventType{openlineage.EventTypeStart, openlineage.EventTypeStart, openlineage.EventTypeComplete}
+	if !slices.Equal(types, want) {
+		t.Errorf("events on stderr = %v, want %v", types, want)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// rawEvent is a JSON value read from an event file.
+type rawEvent struct {
+	// line is the line number the value starts at
+	line int
+	data json.RawMessage
+}
+
+// positionError is an error at a line of an event file.
+type positionError struct {
+	file string
+	line int
+	err  error
+}
+
+func (e positionError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.file, e.line, e.err)
+}
+
+func (e positionError) Unwrap() error {
+	return e.err
+}
+
+// readFile reads the file named name, or stdin if name is "-".
+func (a *app) readFile(name string) ([]byte, error) {
+	if name == "-" {
+		return io.ReadAll(a.stdin)
+	}
+
+	return os.ReadFile(name)
+}
+
+// decodeRaw splits the contents of an event file into JSON values.
+// Files usually contain one event per line (NDJSON), but any sequence of JSON values,
+// such as the pretty-printed output of the console transport, is accepted.
+// Decoding stops at the first syntax error, which is returned as a positionError.
+func decodeRaw(file string, data []byte) ([]rawEvent, error) {
+	var (
+		events []rawEvent
+		offset int
+		line   = 1
+	)
+
+	// lineAt returns the line number of position pos, counting from the last position it was called with
+	lineAt := func(pos int) int {
+		line += bytes.Count(data[offset:pos], []byte{'\n'})
+		offset = pos
+
+		return line
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var raw json.RawMessage
+
+		err := dec.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			return events, nil
+		}
+
+		if err != nil {
+			pos := int(dec.InputOffset())
+
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				pos = int(syntaxErr.Offset)
+			}
+
+			return events, positionError{file: file, line: lineAt(min(pos, len(data))), err: err}
+		}
+
+		start := int(dec.InputOffset()) - len(raw)
+		events = append(events, rawEvent{line: lineAt(start), data: raw})
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/google/uuid"
+	"github.com/tidwall/pretty"
+)
+
+func eventJSON(t *testing.T, eventType openlineage.EventType, runID string) string {
+	t.Helper()
+
+	event := openlineage.NewNamespacedRunEvent(eventType, uuid.New(), "load", "files").AsEmittable()
+	event.Run.RunID = runID
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+// writeEvents writes an NDJSON file with an invalid event on line 3, and a pretty-printed file.
+func writeEvents(t *testing.T) (string, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	ndjson := filepath.Join(dir, "events.ndjson")
+	prettyFile := filepath.Join(dir, "pretty.json")
+
+	lines := []string{
+		eventJSON(t, openlineage.EventTypeStart, uuid.NewString()),
+		"",
+		eventJSON(t, openlineage.EventTypeRunning, "not-a-uuid"),
+		eventJSON(t, openlineage.EventTypeComplete, uuid.NewString()),
+	}
+
+	if err := os.WriteFile(ndjson, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	prettyEvents := append(pretty.Pretty([]byte(eventJSON(t, openlineage.EventTypeStart, uuid.NewString()))),
+		pretty.Pretty([]byte(eventJSON(t, openlineage.EventTypeComplete, uuid.NewString())))...)
+	if err := os.WriteFile(prettyFile, prettyEvents, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return ndjson, prettyFile
+}
+
+func Test_Validate(t *testing.T) {
+	ndjson, prettyFile := writeEvents(t)
+
+	if _, err := execute(t, "validate", prettyFile); err != nil {
+		t.Errorf("pretty-printed file is invalid: %s", err)
+	}
+
+	out, err := execute(t, "validate", prettyFile, ndjson)
+	if err == nil || err.Error() != "1 of 5 events are invalid" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if want := ndjson + `:3: run.runId: is not a valid UUID: "not-a-uuid"`; out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+
+	syntax := filepath.Join(t.TempDir(), "syntax.ndjson")
+	data := eventJSON(t, openlineage.EventTypeStart, uuid.NewString()) + "\n{\"eventTime\": }\n"
+	if err := os.WriteFile(syntax, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, _ = execute(t, "validate", syntax)
+	if !strings.HasPrefix(out, syntax+":2: ") {
+		t.Errorf("syntax error not reported on line 2: %q", out)
+	}
+
+	// the facet passes the check of required fields, but not the schema
+	facet := strings.Replace(eventJSON(t, openlineage.EventTypeStart, uuid.NewString()),
+		`"run":{`, `"run":{"facets":{"custom":{"_producer":"test"}},`, 1)
+	schemaFile := filepath.Join(t.TempDir(), "schema.ndjson")
+	data = eventJSON(t, openlineage.EventTypeStart, uuid.NewString()) + "\n" + facet + "\n"
+	if err := os.WriteFile(schemaFile, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err = execute(t, "validate", schemaFile)
+	if err == nil || err.Error() != "1 of 2 events are invalid" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if want := schemaFile + ":2: run.facets.custom._schemaURL: is required"; out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+}
+
+func Test_Fmt(t *testing.T) {
+	_, prettyFile := writeEvents(t)
+
+	compact, err := execute(t, "fmt", "-compact", prettyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(compact, "\n")
+	if len(lines) != 2 || !json.Valid([]byte(lines[0])) || !json.Valid([]byte(lines[1])) {
+		t.Fatalf("expected 2 lines of JSON, got %q", compact)
+	}
+
+	var stdout bytes.Buffer
+	a := &app{stdin: strings.NewReader(compact), stdout: &stdout, stderr: &bytes.Buffer{}}
+	if err := a.run(context.Background(), []string{"fmt"}); err != nil {
+		t.Fatal(err)
+	}
+
+	original, _ := os.ReadFile(prettyFile)
+	if stdout.String() != string(original) {
+		t.Errorf("pretty-printing stdin did not restore the original:\n%s", stdout.String())
+	}
+}
+
+func Test_Replay(t *testing.T) {
+	ndjson, _ := writeEvents(t)
+
+	t.Run("dry-run", func(t *testing.T) {
+		srv := newServer(t)
+
+		out, err := execute(t, "replay", "-dry-run", ndjson)
+		if err == nil {
+			t.Error("expected an error for the invalid event")
+		}
+
+		if n := strings.Count(out, ": START files/load run=") + strings.Count(out, ": COMPLETE files/load run="); n != 2 {
+			t.Errorf("expected 2 summaries, got %q", out)
+		}
+
+		if len(srv.Events()) != 0 {
+			t.Error("dry run sent events")
+		}
+	})
+
+	t.Run("rate-limited", func(t *testing.T) {
+		srv := newServer(t)
+
+		start := time.Now()
+		if _, err := execute(t, "replay", "-rate", "10", ndjson); err == nil {
+			t.Error("expected an error for the invalid event")
+		}
+
+		if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+			t.Errorf("2 events at 10 per second took %s", elapsed)
+		}
+
+		events := srv.Events()
+		if len(events) != 2 || *events[0].EventType != openlineage.EventTypeStart || *events[1].EventType != openlineage.EventTypeComplete {
+			t.Errorf("unexpected events: %+v", events)
+		}
+	})
+
+	t.Run("config-file", func(t *testing.T) {
+		// the environment overrides the URL in the file
+		srv := newServer(t)
+
+		config := filepath.Join(t.TempDir(), "openlineage.yml")
+		data := "transport:\n  type: http\n  http:\n    url: http://localhost:1\n"
+		if err := os.WriteFile(config, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		execute(t, "replay", "-config", config, ndjson)
+
+		if n := len(srv.Events()); n != 2 {
+			t.Errorf("expected 2 events, got %d", n)
+		}
+	})
+
+	t.Run("config-file-console", func(t *testing.T) {
+		config := filepath.Join(t.TempDir(), "openlineage.yml")
+		if err := os.WriteFile(config, []byte("transport:\n  type: console\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		var stdout, stderr bytes.Buffer
+		a := &app{stdout: &stdout, stderr: &stderr}
+		if err := a.run(context.Background(), []string{"replay", "-config", config, ndjson}); err == nil {
+			t.Error("expected an error for the invalid event")
+		}
+
+		if strings.Contains(stdout.String(), "{") {
+			t.Errorf("replayed events were written to stdout: %q", stdout.String())
+		}
+
+		if n := strings.Count(stderr.String(), `"eventType":`); n != 2 {
+			t.Errorf("expected 2 events on stderr, got %q", stderr.String())
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bufio"
+	"context"
+
+	"github.com/tidwall/pretty"
+)
+
+func runFmt(_ context.Context, a *app, args []string) error {
+	var compact bool
+
+	fs := a.newFlagSet("fmt", "[-compact] [file...]")
+	fs.BoolVar(&compact, "compact", false, "print every event on a single line, as NDJSON")
+	fs.Usage = withFooter(fs.Usage, a, "Without files, stdin is read.")
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	w := bufio.NewWriter(a.stdout)
+	defer w.Flush()
+
+	for _, file := range files {
+		data, err := a.readFile(file)
+		if err != nil {
+			return err
+		}
+
+		raws, err := decodeRaw(file, data)
+		for _, raw := range raws {
+			if compact {
+				w.Write(pretty.Ugly(raw.data))
+				w.WriteByte('\n')
+			} else {
+				w.Write(pretty.Pretty(raw.data))
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,152 @@
+// Command openlineage emits OpenLineage events from shell scripts and other non-Go programs.
+//
+// Events are emitted using the client configuration from the environment, see [openlineage.ConfigFromEnv].
+//
+// Usage:
+//
+//	openlineage <command> [flags]
+//
+// Run "openlineage help" for a list of commands, and "openlineage <command> -h" for their flags.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+)
+
+// command is a subcommand of the CLI.
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, a *app, args []string) error
+}
+
+var commands = []command{
+	{name: "start", summary: "emit a START event and print the run ID", run: runStart},
+	{name: "complete", summary: "emit a COMPLETE event for a run", run: runComplete},
+	{name: "fail", summary: "emit a FAIL event for a run", run: runFail},
+	{name: "event", summary: "emit a run event of any type, or a job event", run: runEvent},
+	{name: "dataset", summary: "emit a dataset event", run: runDataset},
+	{name: "exec", summary: "run a command as a run, emitting START and COMPLETE, FAIL or ABORT", run: runExec},
+	{name: "validate", summary: "check event files for the fields required by the specification", run: runValidate},
+	{name: "fmt", summary: "pretty-print or compact event files", run: runFmt},
+	{name: "replay", summary: "send the events in event files", run: runReplay},
+}
+
+// app holds the streams the CLI uses.
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	// signals replaces the signals exec listens for, if set
+	signals <-chan os.Signal
+}
+
+// usageError is returned for invalid invocations, after which usage has already been printed.
+type usageError struct {
+	err error
+
+	// reported is set if err has already been printed, as the flag package does for parse errors
+	reported bool
+}
+
+func (e usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e usageError) Unwrap() error {
+	return e.err
+}
+
+func main() {
+	a := &app{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+
+	err := a.run(context.Background(), os.Args[1:])
+
+	var (
+		usage usageError
+		exit  exitError
+	)
+
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return
+	case errors.As(err, &exit):
+		os.Exit(exit.code)
+	case errors.As(err, &usage):
+		if !usage.reported {
+			fmt.Fprintf(a.stderr, "openlineage: %s\n", err)
+		}
+		os.Exit(2)
+	default:
+		fmt.Fprintf(a.stderr, "openlineage: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func (a *app) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		a.usage()
+		return usageError{err: errors.New("no command specified")}
+	}
+
+	name := args[0]
+	if name == "help" || name == "-h" || name == "--help" {
+		a.usage()
+		return nil
+	}
+
+	for _, c := range commands {
+		if c.name == name {
+			return c.run(ctx, a, args[1:])
+		}
+	}
+
+	a.usage()
+
+	return usageError{err: fmt.Errorf("unknown command %q", name)}
+}
+
+func (a *app) usage() {
+	fmt.Fprintln(a.stderr, "Usage: openlineage <command> [flags]")
+	fmt.Fprintln(a.stderr)
+	fmt.Fprintln(a.stderr, "Commands:")
+
+	tw := tabwriter.NewWriter(a.stderr, 0, 4, 2, ' ', 0)
+	for _, c := range commands {
+		fmt.Fprintf(tw, "  %s\t%s\n", c.name, c.summary)
+	}
+	tw.Flush()
+
+	fmt.Fprintln(a.stderr)
+	fmt.Fprintln(a.stderr, `Run "openlineage <command> -h" for the flags of a command.`)
+	fmt.Fprintln(a.stderr, "The client is configured through the environment, e.g. OPENLINEAGE_URL and OPENLINEAGE_CONFIG.")
+}
+
+// newFlagSet creates a FlagSet for a subcommand that reports errors instead of exiting.
+func (a *app) newFlagSet(name, synopsis string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage: openlineage %s %s\n\n", name, synopsis)
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
+
+// parse parses args into fs, wrapping errors other than [flag.ErrHelp] in a usageError.
+func parse(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+
+	return usageError{err: err, reported: true}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/lineagetest"
+	"github.com/google/uuid"
+)
+
+// newServer starts a lineagetest server and configures the environment to emit to it.
+func newServer(t *testing.T) *lineagetest.Server {
+	t.Helper()
+
+	srv := lineagetest.NewServer()
+	t.Cleanup(srv.Close)
+
+	t.Setenv("OPENLINEAGE_TRANSPORT", "http")
+	t.Setenv("OPENLINEAGE_URL", srv.URL)
+	t.Setenv("OPENLINEAGE_NAMESPACE", "cli")
+
+	return srv
+}
+
+func execute(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	a := &app{stdout: &stdout, stderr: &stderr}
+
+	err := a.run(context.Background(), args)
+
+	return strings.TrimSpace(stdout.String()), err
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "facets.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func Test_StartComplete(t *testing.T) {
+	srv := newServer(t)
+
+	runID, err := execute(t, "start",
+		"-job", "load",
+		"-input", "postgres://db:5432:public.users",
+		"-output", "s3://bucket:users.parquet",
+		"-run-facets", writeFile(t, `{"nominalTime": {"nominalStartTime": "2024-01-01T00:00:00Z"}, "custom": {"key": "value"}}`),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := uuid.Parse(runID); err != nil {
+		t.Fatalf("start printed %q instead of a run ID", runID)
+	}
+
+	t.Setenv(runIDEnv, runID)
+	if _, err := execute(t, "complete", "-job", "load"); err != nil {
+		t.Fatal(err)
+	}
+
+	events := srv.Events()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	start, complete := events[0], events[1]
+	if *start.EventType != openlineage.EventTypeStart || *complete.EventType != openlineage.EventTypeComplete {
+		t.Errorf("unexpected event types %s, %s", *start.EventType, *complete.EventType)
+	}
+
+	if start.Run.RunID != runID || complete.Run.RunID != runID {
+		t.Errorf("events do not share run ID %s", runID)
+	}
+
+	if start.Job.Namespace != "cli" {
+		t.Errorf("expected namespace cli, got %s", start.Job.Namespace)
+	}
+
+	if len(start.Inputs) != 1 || start.Inputs[0].Namespace != "postgres://db:5432" || start.Inputs[0].Name != "public.users" {
+		t.Errorf("unexpected inputs: %+v", start.Inputs)
+	}
+
+	if len(start.Outputs) != 1 || start.Outputs[0].Name != "users.parquet" {
+		t.Errorf("unexpected outputs: %+v", start.Outputs)
+	}
+
+	runFacets := start.Run.Facets
+	if runFacets == nil || runFacets.NominalTime == nil || runFacets.Custom["custom"] == nil {
+		t.Errorf("run facets were not read from file: %+v", runFacets)
+	}
+}
+
+func Test_Fail(t *testing.T) {
+	srv := newServer(t)
+
+	runID := uuid.NewString()
+	if _, err := execute(t, "fail", "-job", "load", "-run-id", runID, "-message", "disk full"); err != nil {
+		t.Fatal(err)
+	}
+
+	event := srv.Events()[0]
+	if *event.EventType != openlineage.EventTypeFail || event.Run.RunID != runID {
+		t.Errorf("unexpected event: %+v", event)
+	}
+
+	if event.Run.Facets == nil || event.Run.Facets.ErrorMessage == nil || event.Run.Facets.ErrorMessage.Message != "disk full" {
+		t.Errorf("expected error message facet, got %+v", event.Run.Facets)
+	}
+}
+
+func Test_Event(t *testing.T) {
+	srv := newServer(t)
+
+	if _, err := execute(t, "event", "-job", "load", "-type", "running", "-run-id", uuid.NewString()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := execute(t, "event", "-job", "load", "-namespace", "static", "-output", "ns:table"); err != nil {
+		t.Fatal(err)
+	}
+
+	events := srv.Events()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	if *events[0].EventType != openlineage.EventTypeRunning {
+		t.Errorf("expected RUNNING event, got %s", *events[0].EventType)
+	}
+
+	job := events[1]
+	if job.Run != nil || job.EventType != nil || job.Job.Namespace != "static" || len(job.Outputs) != 1 {
+		t.Errorf("expected a job event, got %+v", job)
+	}
+}
+
+func Test_Dataset(t *testing.T) {
+	srv := newServer(t)
+
+	facetsFile := writeFile(t, `{"documentation": {"description": "all users"}}`)
+	if _, err := execute(t, "dataset", "-dataset", "ns:users", "-facets", facetsFile); err != nil {
+		t.Fatal(err)
+	}
+
+	event := srv.Events()[0]
+	if event.Dataset == nil || event.Dataset.Name != "users" || event.Dataset.Facets.DatasetDocumentation.Description != "all users" {
+		t.Errorf("unexpected dataset event: %+v", event.Dataset)
+	}
+}
+
+func Test_UsageErrors(t *testing.T) {
+	newServer(t)
+	t.Setenv(runIDEnv, "")
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no-command", args: nil},
+		{name: "unknown-command", args: []string{"frobnicate"}},
+		{name: "missing-job", args: []string{"start"}},
+		{name: "invalid-dataset", args: []string{"start", "-job", "load", "-input", "no-namespace"}},
+		{name: "missing-run-id", args: []string{"complete", "-job", "load"}},
+		{name: "invalid-run-id", args: []string{"complete", "-job", "load", "-run-id", "123"}},
+		{name: "invalid-event-type", args: []string{"event", "-job", "load", "-type", "DONE", "-run-id", uuid.NewString()}},
+		{name: "missing-dataset", args: []string{"dataset"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := execute(t, tt.args...)
+
+			var usage usageError
+			if !errors.As(err, &usage) {
+				t.Errorf("expected a usage error, got %v", err)
+			}
+		})
+	}
+}
+
+func Test_Start_Console(t *testing.T) {
+	t.Setenv("OPENLINEAGE_TRANSPORT", "console")
+	t.Setenv("OPENLINEAGE_NAMESPACE", "cli")
+
+	var stdout, stderr bytes.Buffer
+	a := &app{stdout: &stdout, stderr: &stderr}
+
+	if err := a.run(context.Background(), []string{"start", "-job", "load"}); err != nil {
+		t.Fatal(err)
+	}
+
+	runID := strings.TrimSpace(stdout.String())
+	if _, err := uuid.Parse(runID); err != nil {
+		t.Fatalf("start printed %q instead of only a run ID", runID)
+	}
+
+	var event openlineage.Event
+	if err := json.Unmarshal(stderr.Bytes(), &event); err != nil {
+		t.Fatalf("console transport did not write the event to stderr: %s: %q", err, stderr.String())
+	}
+
+	if event.Run == nil || event.Run.RunID != runID {
+		t.Errorf("event on stderr is not for run %s: %+v", runID, event.Run)
+	}
+}
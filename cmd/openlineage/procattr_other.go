@@ -0,0 +1,8 @@
+//go:build !unix
+
+package main
+
+import "os/exec"
+
+// isolateProcessGroup does nothing on platforms without process groups.
+func isolateProcessGroup(*exec.Cmd) {}
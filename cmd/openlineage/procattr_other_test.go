@@ -0,0 +1,9 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+func processGroup() int {
+	return os.Getpid()
+}
@@ -0,0 +1,15 @@
+//go:build unix
+
+package main
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// isolateProcessGroup starts cmd in a process group of its own.
+// Signals sent to the whole group of the CLI, such as an interrupt from the terminal, then only reach the command
+// once, when exec forwards them.
+func isolateProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
@@ -0,0 +1,33 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+	"testing"
+)
+
+func processGroup() int {
+	return syscall.Getpgrp()
+}
+
+func Test_Exec_ProcessGroup(t *testing.T) {
+	newServer(t)
+	t.Setenv(helperEnv, "pgid")
+
+	out, err := execute(t, "exec", "-job", "load", "--", os.Args[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pgid, err := strconv.Atoi(out)
+	if err != nil {
+		t.Fatalf("command printed %q instead of its process group", out)
+	}
+
+	if pgid == processGroup() {
+		t.Error("command runs in the process group of the CLI")
+	}
+}
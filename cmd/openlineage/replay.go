@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+)
+
+func runReplay(ctx context.Context, a *app, args []string) error {
+	var (
+		configFile string
+		rate       float64
+		dryRun     bool
+	)
+
+	fs := a.newFlagSet("replay", "[-config <file>] [-rate <n>] [-dry-run] [file...]")
+	fs.StringVar(&configFile, "config", "", "client configuration file, overridden by the environment (default: OPENLINEAGE_CONFIG or the default locations)")
+	fs.Float64Var(&rate, "rate", 0, "maximum number of events sent per second, 0 for no limit")
+	fs.BoolVar(&dryRun, "dry-run", false, "print the events that would be sent instead of sending them")
+	fs.Usage = withFooter(fs.Usage, a, "Events are sent as they were captured. Invalid events are reported and skipped.\n"+
+		"Without files, stdin is read.")
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	events, failed, err := a.readEvents(fs.Args(), false)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		for _, e := range events {
+			fmt.Fprintf(a.stdout, "%s:%d: %s\n", e.file, e.line, e.event.Summary())
+		}
+
+		return replayResult(len(events), failed)
+	}
+
+	cfg, err := replayConfig(configFile)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.newClientFromConfig(cfg, "")
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	var tick <-chan time.Time
+	if rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	sent := 0
+	for i, e := range events {
+		if tick != nil && i > 0 {
+			select {
+			case <-tick:
+			case <-ctx.Done():
+				return fmt.Errorf("replay interrupted after %d events: %w", sent, ctx.Err())
+			}
+		}
+
+		if err := client.Emit(ctx, e.event); err != nil {
+			fmt.Fprintln(a.stdout, positionError{file: e.file, line: e.line, err: err})
+			failed++
+
+			continue
+		}
+
+		sent++
+	}
+
+	return replayResult(sent, failed)
+}
+
+// replayConfig reads the configuration from configFile instead of the default locations, if it is not empty.
+// The environment overrides its values, as for the other commands.
+func replayConfig(configFile string) (openlineage.ClientConfig, error) {
+	if configFile == "" {
+		return openlineage.ConfigFromEnv()
+	}
+
+	cfg, _, err := openlineage.LoadConfigFile(configFile)
+
+	return cfg, err
+}
+
+func replayResult(sent, failed int) error {
+	if failed > 0 {
+		return fmt.Errorf("%d of %d events could not be replayed", failed, sent+failed)
+	}
+
+	return nil
+}
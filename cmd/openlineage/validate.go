@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/schema"
+)
+
+// fileEvent is an event decoded from an event file.
+type fileEvent struct {
+	file  string
+	line  int
+	event openlineage.Event
+}
+
+// readEvents decodes and validates the events in files, reading stdin if files is empty.
+// If checkSchema is set, events are first validated against the JSON schema of the specification.
+// Problems are written to stdout with their position, and counted in the returned number of invalid events.
+// Errors reading a file are returned.
+func (a *app) readEvents(files []string, checkSchema bool) ([]fileEvent, int, error) {
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	var (
+		events  []fileEvent
+		invalid int
+	)
+
+	for _, file := range files {
+		data, err := a.readFile(file)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		raws, err := decodeRaw(file, data)
+		if err != nil {
+			fmt.Fprintln(a.stdout, err)
+			invalid++
+		}
+
+		for _, raw := range raws {
+			if checkSchema {
+				if err := schema.ValidateEvent(raw.data); err != nil {
+					a.printFieldErrors(file, raw.line, err)
+					invalid++
+
+					continue
+				}
+			}
+
+			var event openlineage.Event
+			if err := json.Unmarshal(raw.data, &event); err != nil {
+				fmt.Fprintln(a.stdout, positionError{file: file, line: raw.line, err: err})
+				invalid++
+
+				continue
+			}
+
+			if err := openlineage.ValidateEvent(event); err != nil {
+				a.printFieldErrors(file, raw.line, err)
+				invalid++
+
+				continue
+			}
+
+			events = append(events, fileEvent{file: file, line: raw.line, event: event})
+		}
+	}
+
+	return events, invalid, nil
+}
+
+// printFieldErrors writes each problem in err to stdout, at the given line of file.
+func (a *app) printFieldErrors(file string, line int, err error) {
+	var fieldErrs openlineage.FieldErrors
+	if !errors.As(err, &fieldErrs) {
+		fmt.Fprintln(a.stdout, positionError{file: file, line: line, err: err})
+		return
+	}
+
+	for _, fe := range fieldErrs {
+		fmt.Fprintln(a.stdout, positionError{file: file, line: line, err: fe})
+	}
+}
+
+func runValidate(_ context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("validate", "[file...]")
+	fs.Usage = withFooter(fs.Usage, a, "Files contain one event per line, or any sequence of JSON events. Without files, stdin is read.\n"+
+		"Problems are printed with the line number of the event they were found in.\n"+
+		"Events are checked against the JSON schema of the specification, then for valid timestamps and UUIDs.")
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	events, invalid, err := a.readEvents(fs.Args(), true)
+	if err != nil {
+		return err
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d events are invalid", invalid, invalid+len(events))
+	}
+
+	return nil
+}
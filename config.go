@@ -2,14 +2,25 @@ package openlineage
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/ThijsKoot/openlineage-go/pkg/transport"
 	"github.com/sethvargo/go-envconfig"
 	"gopkg.in/yaml.v3"
 )
 
+// ConfigFileName is the name of the configuration file searched for by [ConfigFromEnv].
+const ConfigFileName = "openlineage.yml"
+
 type ClientConfig struct {
 	Transport transport.Config `yaml:"transport"`
 
@@ -18,42 +29,431 @@ type ClientConfig struct {
 
 	// When true, OpenLineage will not emit events (default: false)
 	Disabled bool `yaml:"disabled" env:"OPENLINEAGE_DISABLED, overwrite"`
+
+	// Transports are additional transports by name, which events can be sent to with [Rule.Transport]
+	Transports map[string]transport.Config `yaml:"transports,omitempty"`
+
+	// Rules disable, route or rename events by job and namespace.
+	// Only the first matching rule applies, see [Rule].
+	Rules []Rule `yaml:"rules,omitempty"`
 }
 
+// ConfigSource describes where a configuration value was read from,
+// such as "env:OPENLINEAGE_URL", "file:/etc/openlineage.yml" or "default".
+type ConfigSource string
+
+// ConfigSourceDefault is the source of values that were not configured, but have a default.
+const ConfigSourceDefault ConfigSource = "default"
+
+// ConfigSources maps the YAML path of configuration values, such as "transport.http.url", to their source.
+// Values that were not configured and have no default are absent.
+type ConfigSources map[string]ConfigSource
+
 // ConfigFromEnv attempts to parse [ClientConfig] from the environment.
-// If OPENLINEAGE_CONFIG_FILE is specified, it will be read first.
+// A configuration file is read first, see [LoadConfig] for where it is searched for.
 // Environment variables take precedence over values from the configuration file.
 func ConfigFromEnv() (ClientConfig, error) {
-	var config ClientConfig
+	config, _, err := LoadConfig()
+
+	return config, err
+}
 
+// LoadConfig parses [ClientConfig] like [ConfigFromEnv], and reports the source of each value.
+//
+// The configuration file is the first one found of:
+//   - the file named by OPENLINEAGE_CONFIG
+//   - openlineage.yml in the working directory
+//   - openlineage.yml in the directory of the executable
+//   - $HOME/.openlineage/openlineage.yml
+//
+// It is not an error if none of these exist, unless OPENLINEAGE_CONFIG is set.
+func LoadConfig() (ClientConfig, ConfigSources, error) {
 	configFile := os.Getenv("OPENLINEAGE_CONFIG")
+	if configFile == "" {
+		configFile = findConfigFile()
+	} else if _, err := os.Stat(configFile); err != nil {
+		return ClientConfig{}, nil, fmt.Errorf("parsing OPENLINEAGE_CONFIG: %w", err)
+	}
+
+	return loadConfig(configFile)
+}
+
+// LoadConfigFile is like [LoadConfig], but reads the configuration file at location instead of searching for one.
+// Environment variables override its values in the same way.
+func LoadConfigFile(location string) (ClientConfig, ConfigSources, error) {
+	return loadConfig(location)
+}
+
+// loadConfig reads configFile, if not empty, and applies the environment to it.
+func loadConfig(configFile string) (ClientConfig, ConfigSources, error) {
+	var (
+		config  ClientConfig
+		sources = ConfigSources{}
+	)
+
 	if configFile != "" {
-		c, err := ConfigFromFile(configFile)
+		c, paths, err := readConfigFile(configFile)
 		if err != nil {
-			return ClientConfig{}, fmt.Errorf("parsing OPENLINEAGE_CONFIG_FILE: %w", err)
+			return ClientConfig{}, nil, fmt.Errorf("parsing %s: %w", configFile, err)
 		}
 
 		config = c
+
+		source := ConfigSource("file:" + configFile)
+		for _, p := range paths {
+			sources[p] = source
+		}
 	}
 
 	if err := envconfig.Process(context.Background(), &config); err != nil {
-		return ClientConfig{}, fmt.Errorf("unable to parse config from environment: %w", err)
+		return ClientConfig{}, nil, fmt.Errorf("unable to parse config from environment: %w", err)
 	}
 
-	return config, nil
+	for _, f := range configFields(reflect.TypeOf(config), "") {
+		_, fromEnv := os.LookupEnv(f.env)
+		_, fromFile := sources[f.path]
+
+		switch {
+		case f.env != "" && fromEnv:
+			sources[f.path] = ConfigSource("env:" + f.env)
+		case !fromFile && f.hasDefault:
+			sources[f.path] = ConfigSourceDefault
+		}
+	}
+
+	return config, sources, nil
 }
 
 // ConfigFromFile reads a configuration file in YAML-format from the specified location.
+// References to environment variables in values, written as ${VAR} or ${VAR:-default}, are replaced by their value.
 func ConfigFromFile(location string) (ClientConfig, error) {
+	cfg, _, err := readConfigFile(location)
+
+	return cfg, err
+}
+
+// readConfigFile reads a configuration file, returning the paths of the values it contains.
+func readConfigFile(location string) (ClientConfig, []string, error) {
 	f, err := os.ReadFile(location)
 	if err != nil {
-		return ClientConfig{}, fmt.Errorf("read config file: %w", err)
+		return ClientConfig{}, nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(f, &doc); err != nil {
+		return ClientConfig{}, nil, fmt.Errorf("unmarshal config file: %w", err)
 	}
 
+	// an empty file has no content
+	if len(doc.Content) == 0 {
+		return ClientConfig{}, nil, nil
+	}
+
+	// decode strictly, reporting all unknown fields with their path
+	var errs FieldErrors
+	checkFields(&errs, doc.Content[0], reflect.TypeOf(ClientConfig{}), "")
+
+	if len(errs) > 0 {
+		return ClientConfig{}, nil, errs
+	}
+
+	var paths []string
+	walkConfigNode(doc.Content[0], "", func(path string, value *yaml.Node) {
+		if expanded := expandEnv(value.Value); expanded != value.Value {
+			value.Value = expanded
+
+			// resolve the type of unquoted values again, so that e.g. ${DISABLED} can decode into a bool
+			if value.Style == 0 {
+				value.Tag = ""
+			}
+		}
+
+		paths = append(paths, path)
+	})
+
 	var cfg ClientConfig
-	if err := yaml.Unmarshal(f, &cfg); err != nil {
-		return ClientConfig{}, fmt.Errorf("unmarshal config file: %w", err)
+	if err := doc.Decode(&cfg); err != nil {
+		return ClientConfig{}, nil, fmt.Errorf("unmarshal config file: %w", err)
+	}
+
+	return cfg, paths, nil
+}
+
+// Validate checks that the configuration describes a usable client.
+// All problems are reported at once as [FieldErrors], with the YAML path of each field.
+// [NewClient] validates its configuration unless the client is disabled.
+func (c ClientConfig) Validate() error {
+	var errs FieldErrors
+
+	validateTransport(&errs, "transport", c.Transport)
+
+	names := make([]string, 0, len(c.Transports))
+	for name := range c.Transports {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		validateTransport(&errs, "transports."+name, c.Transports[name])
+	}
+
+	for i, r := range c.Rules {
+		if _, ok := c.Transports[r.Transport]; r.Transport != "" && !ok {
+			errs.add(fmt.Sprintf("rules[%d].transport", i), "unknown transport %q, expected one of the names in transports", r.Transport)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// validateTransport checks the transport configured at path.
+func validateTransport(errs *FieldErrors, path string, t transport.Config) {
+	switch t.Type {
+	case "":
+		errs.add(path+".type", "is required, expected one of: %s", transportTypes())
+	case transport.TransportTypeConsole:
+		if t.Console == nil {
+			break
+		}
+
+		addConsoleErrors(errs, path+".console", t.Console.Validate())
+	case transport.TransportTypeHTTP:
+		if t.HTTP == nil {
+			errs.add(path+".http", "is required for transport type %s", t.Type)
+			break
+		}
+
+		validateURL(errs, path+".http.url", t.HTTP.URL)
+	case transport.TransportTypeSlog:
+		if t.Slog == nil || t.Slog.Level == "" {
+			break
+		}
+
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(t.Slog.Level)); err != nil {
+			errs.add(path+".slog.level", "unknown level %q, expected one of: debug, info, warn, error", t.Slog.Level)
+		}
+	default:
+		// registered transports validate their own configuration when they are created
+		if !transport.IsRegistered(t.Type) {
+			errs.add(path+".type", "unknown transport type %q, expected one of: %s", t.Type, transportTypes())
+		}
+	}
+}
+
+// addConsoleErrors adds the errors returned by [transport.ConsoleConfig.Validate] below path.
+func addConsoleErrors(errs *FieldErrors, path string, err error) {
+	if err == nil {
+		return
+	}
+
+	all := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		all = joined.Unwrap()
+	}
+
+	for _, err := range all {
+		var fieldErr *transport.ConsoleFieldError
+		if errors.As(err, &fieldErr) {
+			errs.add(path+"."+fieldErr.Field, "%s", fieldErr.Message)
+		} else {
+			errs.add(path, "%s", err)
+		}
+	}
+}
+
+// transportTypes lists the available transport types for error messages.
+func transportTypes() string {
+	types := transport.Types()
+
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// validateURL checks that value is an absolute HTTP(S) URL.
+func validateURL(errs *FieldErrors, path, value string) {
+	if value == "" {
+		errs.add(path, "is required")
+		return
+	}
+
+	u, err := url.Parse(value)
+	switch {
+	case err != nil:
+		errs.add(path, "is not a valid URL: %q", value)
+	case u.Scheme != "http" && u.Scheme != "https":
+		errs.add(path, "must use scheme http or https: %q", value)
+	case u.Host == "":
+		errs.add(path, "has no host: %q", value)
+	}
+}
+
+// findConfigFile returns the first configuration file that exists in the default locations, or an empty string.
+func findConfigFile() string {
+	candidates := []string{ConfigFileName}
+
+	if exe, err := os.Executable(); err == nil {
+		candidates = append(candidates, filepath.Join(filepath.Dir(exe), ConfigFileName))
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, filepath.Join(home, ".openlineage", ConfigFileName))
+	}
+
+	for _, c := range candidates {
+		if info, err := os.Stat(c); err == nil && info.Mode().IsRegular() {
+			return c
+		}
+	}
+
+	return ""
+}
+
+// walkConfigNode calls fn for every scalar value in a YAML node, with its path such as "transport.http.url" or "rules[0].job".
+func walkConfigNode(node *yaml.Node, path string, fn func(path string, value *yaml.Node)) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			walkConfigNode(node.Content[i+1], joinPath(path, node.Content[i].Value), fn)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			walkConfigNode(item, fmt.Sprintf("%s[%d]", path, i), fn)
+		}
+	case yaml.ScalarNode:
+		fn(path, node)
+	}
+}
+
+// checkFields reports the keys in node that are not fields of t, following nested structs, maps and slices.
+// Sections of transports added with [transport.Register] are accepted, as they are decoded by the transport itself.
+func checkFields(errs *FieldErrors, node *yaml.Node, t reflect.Type, path string) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		fields := map[string]reflect.Type{}
+		inline := false
+
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+
+			name, opts, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+			switch {
+			case strings.Contains(opts, "inline"):
+				inline = true
+			case name != "" && name != "-":
+				fields[name] = sf.Type
+			}
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			p := joinPath(path, key.Value)
+
+			ft, ok := fields[key.Value]
+			switch {
+			case ok:
+				checkFields(errs, node.Content[i+1], ft, p)
+			case inline && transport.IsRegistered(transport.TransportType(key.Value)):
+			default:
+				errs.add(p, "unknown field on line %d", key.Line)
+			}
+		}
+	case t.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			checkFields(errs, node.Content[i+1], t.Elem(), joinPath(path, node.Content[i].Value))
+		}
+	case t.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		for i, item := range node.Content {
+			checkFields(errs, item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+// joinPath appends key to the dotted path prefix.
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
+
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// expandEnv replaces ${VAR} and ${VAR:-default} in s.
+// Unset variables without a default are replaced by an empty string.
+func expandEnv(s string) string {
+	return envReference.ReplaceAllStringFunc(s, func(ref string) string {
+		m := envReference.FindStringSubmatch(ref)
+		if v, ok := os.LookupEnv(m[1]); ok && v != "" {
+			return v
+		}
+
+		return m[2]
+	})
+}
+
+// configField is a configuration value, identified by its YAML path.
+type configField struct {
+	path       string
+	env        string
+	hasDefault bool
+}
+
+// configFields lists the fields of the configuration struct t, using their yaml and env tags.
+func configFields(t reflect.Type, prefix string) []configField {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var fields []configField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		if ft.Kind() == reflect.Struct {
+			fields = append(fields, configFields(ft, name)...)
+			continue
+		}
+
+		f := configField{path: name}
+
+		opts := strings.Split(sf.Tag.Get("env"), ",")
+		f.env = strings.TrimSpace(opts[0])
+		for _, o := range opts[1:] {
+			if strings.HasPrefix(strings.TrimSpace(o), "default=") {
+				f.hasDefault = true
+			}
+		}
+
+		fields = append(fields, f)
 	}
 
-	return cfg, nil
+	return fields
 }
@@ -1,6 +1,10 @@
 package openlineage_test
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ThijsKoot/openlineage-go"
@@ -128,6 +132,27 @@ func Test_ConfigFromFile(t *testing.T) {
 				Disabled:  false,
 			},
 		},
+		{
+			name: "rules",
+			file: "testdata/config-rules.yaml",
+			want: openlineage.ClientConfig{
+				Transport: transport.Config{
+					Type: transport.TransportTypeConsole,
+				},
+				Transports: map[string]transport.Config{
+					"internal": {
+						Type: transport.TransportTypeHTTP,
+						HTTP: &transport.HTTPConfig{URL: "https://lineage.internal"},
+					},
+				},
+				Rules: []openlineage.Rule{
+					{Job: "scratch_*", Disabled: true},
+					{Namespace: "pii.*", Transport: "internal"},
+					{Job: "nightly_export", SetNamespace: "reports"},
+				},
+				Namespace: "rules-ns",
+			},
+		},
 	}
 
 	for _, tt := range cases {
@@ -143,3 +168,353 @@ func Test_ConfigFromFile(t *testing.T) {
 		})
 	}
 }
+
+// chdir changes the working directory for the duration of the test.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_LoadConfig(t *testing.T) {
+	const interpolated = `
+namespace: ${OL_TEST_NAMESPACE:-interpolated}
+transport:
+  type: http
+  http:
+    url: https://${OL_TEST_HOST}/lineage
+    apiKey: ${OL_TEST_UNSET}
+`
+
+	tests := []struct {
+		name        string
+		env         map[string]string
+		workdir     string
+		home        string
+		want        openlineage.ClientConfig
+		wantSources openlineage.ConfigSources
+	}{
+		{
+			name:    "working-directory",
+			env:     map[string]string{"OL_TEST_HOST": "marquez:5000"},
+			workdir: interpolated,
+			home:    "namespace: home\n",
+			want: openlineage.ClientConfig{
+				Transport: transport.Config{
+					Type: transport.TransportTypeHTTP,
+					HTTP: &transport.HTTPConfig{
+						URL:      "https://marquez:5000/lineage",
+						Endpoint: transport.DefaultEndpoint,
+					},
+				},
+				Namespace: "interpolated",
+			},
+			wantSources: openlineage.ConfigSources{
+				"namespace":               "file:openlineage.yml",
+				"transport.type":          "file:openlineage.yml",
+				"transport.http.url":      "file:openlineage.yml",
+				"transport.http.apiKey":   "file:openlineage.yml",
+				"transport.http.endpoint": openlineage.ConfigSourceDefault,
+			},
+		},
+		{
+			name: "home",
+			env: map[string]string{
+				"OL_TEST_NAMESPACE":     "from-variable",
+				"OL_TEST_HOST":          "localhost",
+				"OPENLINEAGE_TRANSPORT": "console",
+			},
+			home: interpolated,
+			want: openlineage.ClientConfig{
+				Transport: transport.Config{
+					Type: transport.TransportTypeConsole,
+					HTTP: &transport.HTTPConfig{
+						URL:      "https://localhost/lineage",
+						Endpoint: transport.DefaultEndpoint,
+					},
+				},
+				Namespace: "from-variable",
+			},
+			wantSources: openlineage.ConfigSources{
+				"namespace":               "file:HOME/.openlineage/openlineage.yml",
+				"transport.type":          "env:OPENLINEAGE_TRANSPORT",
+				"transport.http.url":      "file:HOME/.openlineage/openlineage.yml",
+				"transport.http.apiKey":   "file:HOME/.openlineage/openlineage.yml",
+				"transport.http.endpoint": openlineage.ConfigSourceDefault,
+			},
+		},
+		{
+			name: "no-file",
+			env:  map[string]string{"OPENLINEAGE_URL": "https://foo"},
+			want: openlineage.ClientConfig{
+				Transport: transport.Config{
+					HTTP: &transport.HTTPConfig{
+						URL:      "https://foo",
+						Endpoint: transport.DefaultEndpoint,
+					},
+				},
+				Namespace: "default",
+			},
+			wantSources: openlineage.ConfigSources{
+				"namespace":               openlineage.ConfigSourceDefault,
+				"transport.http.url":      "env:OPENLINEAGE_URL",
+				"transport.http.endpoint": openlineage.ConfigSourceDefault,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workdir, home := t.TempDir(), t.TempDir()
+			chdir(t, workdir)
+			t.Setenv("HOME", home)
+
+			if tt.workdir != "" {
+				writeConfig(t, filepath.Join(workdir, openlineage.ConfigFileName), tt.workdir)
+			}
+
+			if tt.home != "" {
+				writeConfig(t, filepath.Join(home, ".openlineage", openlineage.ConfigFileName), tt.home)
+			}
+
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			got, sources, err := openlineage.LoadConfig()
+			if err != nil {
+				t.Fatalf("LoadConfig failed: %s", err)
+			}
+
+			if diff := deep.Equal(tt.want, got); diff != nil {
+				t.Errorf("differences found:\n%s", diff)
+			}
+
+			for path, source := range tt.wantSources {
+				tt.wantSources[path] = openlineage.ConfigSource(strings.Replace(string(source), "HOME", home, 1))
+			}
+
+			if diff := deep.Equal(tt.wantSources, sources); diff != nil {
+				t.Errorf("differences in sources:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_LoadConfig_MissingFile(t *testing.T) {
+	t.Setenv("OPENLINEAGE_CONFIG", "testdata/missing.yaml")
+
+	_, err := openlineage.ConfigFromEnv()
+	if err == nil || !strings.Contains(err.Error(), "OPENLINEAGE_CONFIG:") {
+		t.Errorf("expected error for OPENLINEAGE_CONFIG, got %v", err)
+	}
+}
+
+func Test_ConfigFromFile_UnknownFields(t *testing.T) {
+	_, err := openlineage.ConfigFromFile("testdata/config-unknown.yaml")
+
+	var errs openlineage.FieldErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected FieldErrors, got %v", err)
+	}
+
+	want := openlineage.FieldErrors{
+		{Path: "disabeld", Message: "unknown field on line 2"},
+		{Path: "transport.http.apikey", Message: "unknown field on line 8"},
+		{Path: "transport.kafka", Message: "unknown field on line 9"},
+		{Path: "transports.internal.htp", Message: "unknown field on line 15"},
+		{Path: "rules[0].disable", Message: "unknown field on line 20"},
+	}
+
+	if diff := deep.Equal(want, errs); diff != nil {
+		t.Errorf("differences found:\n%s", diff)
+	}
+}
+
+func Test_ConfigFromFile_InterpolatedTypes(t *testing.T) {
+	t.Setenv("OL_TEST_DISABLED", "true")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "disabled: ${OL_TEST_DISABLED}\nnamespace: \"${OL_TEST_DISABLED}\"\n")
+
+	got, err := openlineage.ConfigFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Disabled || got.Namespace != "true" {
+		t.Errorf("unexpected config: %+v", got)
+	}
+}
+
+func Test_ClientConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		transport transport.Config
+		want      openlineage.FieldErrors
+	}{
+		{
+			name:      "console",
+			transport: transport.Config{Type: transport.TransportTypeConsole, Console: &transport.ConsoleConfig{}},
+		},
+		{
+			name:      "console-without-block",
+			transport: transport.Config{Type: transport.TransportTypeConsole},
+		},
+		{
+			name: "console-format-and-target",
+			transport: transport.Config{Type: transport.TransportTypeConsole, Console: &transport.ConsoleConfig{
+				Format: transport.ConsoleFormatSummary,
+				Target: transport.ConsoleTargetStderr,
+			}},
+		},
+		{
+			name: "console-unknown-format-and-target",
+			transport: transport.Config{Type: transport.TransportTypeConsole, Console: &transport.ConsoleConfig{
+				Format: "yaml",
+				Target: "syslog",
+			}},
+			want: openlineage.FieldErrors{
+				{Path: "transport.console.format", Message: `unknown format "yaml", expected one of: compact, pretty, summary`},
+				{Path: "transport.console.target", Message: `unknown target "syslog", expected one of: stdout, stderr`},
+			},
+		},
+		{
+			name:      "http",
+			transport: transport.Config{Type: transport.TransportTypeHTTP, HTTP: &transport.HTTPConfig{URL: "https://marquez:5000"}},
+		},
+		{
+			name: "missing-type",
+			want: openlineage.FieldErrors{
+				{Path: "transport.type", Message: "is required, expected one of: console, http, slog"},
+			},
+		},
+		{
+			name:      "unknown-type",
+			transport: transport.Config{Type: "kafka"},
+			want: openlineage.FieldErrors{
+				{Path: "transport.type", Message: `unknown transport type "kafka", expected one of: console, http, slog`},
+			},
+		},
+		{
+			name:      "http-without-block",
+			transport: transport.Config{Type: transport.TransportTypeHTTP},
+			want: openlineage.FieldErrors{
+				{Path: "transport.http", Message: "is required for transport type http"},
+			},
+		},
+		{
+			name:      "missing-url",
+			transport: transport.Config{Type: transport.TransportTypeHTTP, HTTP: &transport.HTTPConfig{}},
+			want: openlineage.FieldErrors{
+				{Path: "transport.http.url", Message: "is required"},
+			},
+		},
+		{
+			name:      "relative-url",
+			transport: transport.Config{Type: transport.TransportTypeHTTP, HTTP: &transport.HTTPConfig{URL: "marquez:5000"}},
+			want: openlineage.FieldErrors{
+				{Path: "transport.http.url", Message: `must use scheme http or https: "marquez:5000"`},
+			},
+		},
+		{
+			name:      "bad-scheme",
+			transport: transport.Config{Type: transport.TransportTypeHTTP, HTTP: &transport.HTTPConfig{URL: "ftp://marquez"}},
+			want: openlineage.FieldErrors{
+				{Path: "transport.http.url", Message: `must use scheme http or https: "ftp://marquez"`},
+			},
+		},
+		{
+			name:      "missing-host",
+			transport: transport.Config{Type: transport.TransportTypeHTTP, HTTP: &transport.HTTPConfig{URL: "https:///api"}},
+			want: openlineage.FieldErrors{
+				{Path: "transport.http.url", Message: `has no host: "https:///api"`},
+			},
+		},
+		{
+			name:      "invalid-url",
+			transport: transport.Config{Type: transport.TransportTypeHTTP, HTTP: &transport.HTTPConfig{URL: "http://%zz"}},
+			want: openlineage.FieldErrors{
+				{Path: "transport.http.url", Message: `is not a valid URL: "http://%zz"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := openlineage.ClientConfig{Transport: tt.transport}
+
+			err := cfg.Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("expected no error, got %s", err)
+				}
+
+				return
+			}
+
+			var errs openlineage.FieldErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expected FieldErrors, got %v", err)
+			}
+
+			if diff := deep.Equal(tt.want, errs); diff != nil {
+				t.Errorf("differences found:\n%s", diff)
+			}
+
+			// NewClient must reject the config instead of creating a broken transport
+			if _, err := openlineage.NewClient(cfg); !errors.As(err, &errs) {
+				t.Errorf("NewClient did not return the validation errors: %v", err)
+			}
+		})
+	}
+}
+
+func Test_ClientConfig_Validate_Rules(t *testing.T) {
+	cfg := openlineage.ClientConfig{
+		Transport: transport.Config{Type: transport.TransportTypeConsole},
+		Transports: map[string]transport.Config{
+			"internal": {Type: transport.TransportTypeHTTP, HTTP: &transport.HTTPConfig{}},
+			"audit":    {Type: transport.TransportTypeConsole},
+		},
+		Rules: []openlineage.Rule{
+			{Namespace: "pii.*", Transport: "internal"},
+			{Job: "audit_*", Transport: "audti"},
+		},
+	}
+
+	want := openlineage.FieldErrors{
+		{Path: "transports.internal.http.url", Message: "is required"},
+		{Path: "rules[1].transport", Message: `unknown transport "audti", expected one of the names in transports`},
+	}
+
+	var errs openlineage.FieldErrors
+	if err := cfg.Validate(); !errors.As(err, &errs) {
+		t.Fatalf("expected FieldErrors, got %v", err)
+	}
+
+	if diff := deep.Equal(want, errs); diff != nil {
+		t.Errorf("differences found:\n%s", diff)
+	}
+}
@@ -2,7 +2,6 @@ package openlineage
 
 import (
 	"context"
-	"time"
 
 	"github.com/ThijsKoot/openlineage-go/pkg/facets"
 )
@@ -28,18 +27,24 @@ func (e *DatasetEvent) Emit() {
 	_ = DefaultClient.Emit(context.Background(), e)
 }
 
+// NewDatasetEvent calls [Client.NewDatasetEvent] on [DefaultClient].
 func NewDatasetEvent(
 	name string,
 	namespace string,
 	facets ...facets.DatasetFacet,
+) DatasetEvent {
+	return DefaultClient.NewDatasetEvent(name, namespace, facets...)
+}
+
+// NewDatasetEvent creates a new [DatasetEvent] with EventTime set to the current time of the Client's [Clock].
+func (olc *Client) NewDatasetEvent(
+	name string,
+	namespace string,
+	facets ...facets.DatasetFacet,
 ) DatasetEvent {
 	return DatasetEvent{
-		BaseEvent: BaseEvent{
-			Producer:  producer,
-			SchemaURL: schemaURL,
-			EventTime: time.Now().Format(time.RFC3339),
-		},
-		Dataset: NewDataset(name, namespace, facets...),
+		BaseEvent: olc.newBaseEvent(),
+		Dataset:   NewDataset(name, namespace, facets...),
 	}
 }
 
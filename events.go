@@ -1,6 +1,9 @@
 package openlineage
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/ThijsKoot/openlineage-go/pkg/facets"
 )
 
@@ -65,3 +68,41 @@ func (oe OutputElement) WithFacets(facets ...facets.DatasetFacet) OutputElement
 
 	return oe
 }
+
+// AsEmittable implements Emittable, so that decoded events can be emitted as they are.
+func (e Event) AsEmittable() Event {
+	return e
+}
+
+// Summary describes the event on a single line, such as "START namespace/job run=<id> inputs=2 outputs=1".
+func (e Event) Summary() string {
+	var b strings.Builder
+
+	switch {
+	case e.Run != nil:
+		eventType := EventTypeOther
+		if e.EventType != nil {
+			eventType = *e.EventType
+		}
+
+		fmt.Fprintf(&b, "%s %s run=%s", eventType, e.jobName(), e.Run.RunID)
+	case e.Dataset != nil:
+		fmt.Fprintf(&b, "DATASET %s/%s", e.Dataset.Namespace, e.Dataset.Name)
+	case e.Job != nil:
+		fmt.Fprintf(&b, "JOB %s", e.jobName())
+	}
+
+	if e.Job != nil {
+		fmt.Fprintf(&b, " inputs=%d outputs=%d", len(e.Inputs), len(e.Outputs))
+	}
+
+	return b.String()
+}
+
+func (e Event) jobName() string {
+	if e.Job == nil {
+		return "?"
+	}
+
+	return e.Job.Namespace + "/" + e.Job.Name
+}
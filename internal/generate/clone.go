@@ -5,8 +5,14 @@ import (
 	"os"
 
 	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 )
 
+// specVersion is the OpenLineage release the types are generated from.
+// It is pinned, so that regenerating only changes the code when it is deliberately bumped.
+// It includes version 1-1-0 of ParentRunFacet, which adds parent.root.
+const specVersion = "1.22.0"
+
 func cloneOpenLineage() (string, error) {
 	// Tempdir to clone the repository
 	dir, err := os.MkdirTemp("", "openlineage")
@@ -15,13 +21,15 @@ func cloneOpenLineage() (string, error) {
 	}
 
 	opts := git.CloneOptions{
-		URL:   "https://github.com/openlineage/OpenLineage.git",
-		Depth: 1,
+		URL:           "https://github.com/openlineage/OpenLineage.git",
+		ReferenceName: plumbing.NewTagReferenceName(specVersion),
+		SingleBranch:  true,
+		Depth:         1,
 	}
 
 	// Clones the repository into the given dir, just as a normal git clone does
 	if _, err := git.PlainClone(dir, false, &opts); err != nil {
-		return "", fmt.Errorf("clone repository: %w", err)
+		return "", fmt.Errorf("clone repository at %s: %w", specVersion, err)
 	}
 
 	return dir, nil
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path"
 )
 
 var repoDir string
@@ -31,6 +32,10 @@ func generate() error {
 		return fmt.Errorf("generate openlinage: %w", err)
 	}
 
+	if err := copySchema(); err != nil {
+		return fmt.Errorf("copy schema: %w", err)
+	}
+
 	return nil
 
 }
@@ -102,3 +107,13 @@ func facets() error {
 
 	return nil
 }
+
+// copySchema copies the OpenLineage schema of the spec release into pkg/schema, which embeds it for validation.
+func copySchema() error {
+	schema, err := os.ReadFile(path.Join(repoDir, "spec", "OpenLineage.json"))
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile("pkg/schema/spec/OpenLineage.json", schema, 0o644)
+}
@@ -2,7 +2,6 @@ package openlineage
 
 import (
 	"context"
-	"time"
 
 	"github.com/ThijsKoot/openlineage-go/pkg/facets"
 )
@@ -35,15 +34,9 @@ func (e *JobEvent) Emit() {
 	_ = DefaultClient.Emit(context.Background(), e)
 }
 
+// NewNamespacedJobEvent calls [Client.NewNamespacedJobEvent] on [DefaultClient].
 func NewNamespacedJobEvent(name, namespace string) *JobEvent {
-	return &JobEvent{
-		BaseEvent: BaseEvent{
-			Producer:  producer,
-			SchemaURL: schemaURL,
-			EventTime: time.Now().Format(time.RFC3339),
-		},
-		Job: NewNamespacedJob(name, namespace),
-	}
+	return DefaultClient.NewNamespacedJobEvent(name, namespace)
 }
 
 // NewJobEvent calls [NewNamespacedJobEvent] with [DefaultNamespace].
@@ -51,6 +44,19 @@ func NewJobEvent(name string) *JobEvent {
 	return NewNamespacedJobEvent(name, DefaultNamespace)
 }
 
+// NewNamespacedJobEvent creates a new [JobEvent] with EventTime set to the current time of the Client's [Clock].
+func (olc *Client) NewNamespacedJobEvent(name, namespace string) *JobEvent {
+	return &JobEvent{
+		BaseEvent: olc.newBaseEvent(),
+		Job:       NewNamespacedJob(name, namespace),
+	}
+}
+
+// NewJobEvent calls [Client.NewNamespacedJobEvent] with the Client's namespace.
+func (olc *Client) NewJobEvent(name string) *JobEvent {
+	return olc.NewNamespacedJobEvent(name, olc.Namespace)
+}
+
 // WithFacets sets the supplied instances of [facets.JobFacet] for this event.
 func (j *JobEvent) WithFacets(facets ...facets.JobFacet) *JobEvent {
 	for _, f := range facets {
@@ -76,7 +82,6 @@ func (j *JobEvent) WithOutputs(inputs ...OutputElement) *JobEvent {
 
 // NewNamespacedJob creates a new [Job].
 func NewNamespacedJob(name string, namespace string, jobFacets ...facets.JobFacet) Job {
-
 	var job *facets.JobFacets
 	for _, f := range jobFacets {
 		f.Apply(&job)
@@ -84,7 +89,7 @@ func NewNamespacedJob(name string, namespace string, jobFacets ...facets.JobFace
 
 	return Job{
 		Name:      name,
-		Namespace: DefaultNamespace,
+		Namespace: namespace,
 		Facets:    job,
 	}
 }
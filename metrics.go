@@ -0,0 +1,47 @@
+package openlineage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/ThijsKoot/openlineage-go/pkg/transport"
+)
+
+// kind returns the type of run events, or JOB or DATASET for job and dataset events.
+func (e Event) kind() string {
+	switch {
+	case e.Run != nil || e.EventType != nil:
+		if e.EventType == nil {
+			return string(EventTypeOther)
+		}
+
+		return string(*e.EventType)
+	case e.Dataset != nil:
+		return "DATASET"
+	default:
+		return "JOB"
+	}
+}
+
+// failureReason describes the cause of err in a few words, for use as a label.
+func failureReason(err error) string {
+	var (
+		statusErr *transport.StatusError
+		netErr    net.Error
+	)
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.As(err, &statusErr):
+		return fmt.Sprintf("status_%d", statusErr.StatusCode)
+	case errors.As(err, &netErr):
+		return "network"
+	default:
+		return "error"
+	}
+}
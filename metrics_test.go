@@ -0,0 +1,147 @@
+package openlineage_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/lineagetest"
+	"github.com/ThijsKoot/openlineage-go/pkg/metrics"
+	"github.com/ThijsKoot/openlineage-go/pkg/transport"
+	"github.com/go-test/deep"
+	"github.com/google/uuid"
+)
+
+var _ metrics.Recorder = (*fakeRecorder)(nil)
+
+// fakeRecorder records measurements as strings, without durations.
+type fakeRecorder struct {
+	mu           sync.Mutex
+	measurements []string
+}
+
+func (f *fakeRecorder) record(format string, args ...any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.measurements = append(f.measurements, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeRecorder) Emitted(_ context.Context, transport, eventType string, _ time.Duration) {
+	f.record("emitted %s %s", transport, eventType)
+}
+
+func (f *fakeRecorder) Failed(_ context.Context, transport, eventType, reason string, _ time.Duration) {
+	f.record("failed %s %s %s", transport, eventType, reason)
+}
+
+func (f *fakeRecorder) Retried(_ context.Context, transport string) {
+	f.record("retried %s", transport)
+}
+
+func (f *fakeRecorder) QueueDepth(_ context.Context, delta int64) {
+	f.record("queue %+d", delta)
+}
+
+func (f *fakeRecorder) Dropped(_ context.Context, reason string) {
+	f.record("dropped %s", reason)
+}
+
+func Test_Client_Metrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		disabled bool
+		faults   []lineagetest.Fault
+		event    func(c *openlineage.Client) openlineage.Emittable
+		want     []string
+	}{
+		{
+			name: "run-event",
+			event: func(c *openlineage.Client) openlineage.Emittable {
+				return c.NewRunEvent(openlineage.EventTypeStart, uuid.New(), "job")
+			},
+			want: []string{"emitted http START"},
+		},
+		{
+			name: "job-event",
+			event: func(c *openlineage.Client) openlineage.Emittable {
+				return c.NewJobEvent("job")
+			},
+			want: []string{"emitted http JOB"},
+		},
+		{
+			name:   "retried",
+			faults: []lineagetest.Fault{{StatusCode: http.StatusServiceUnavailable, Times: 1}},
+			event: func(c *openlineage.Client) openlineage.Emittable {
+				e := c.NewDatasetEvent("table", "db")
+				return &e
+			},
+			want: []string{"retried http", "emitted http DATASET"},
+		},
+		{
+			name:   "rejected",
+			faults: []lineagetest.Fault{{StatusCode: http.StatusBadRequest, Times: 1}},
+			event: func(c *openlineage.Client) openlineage.Emittable {
+				return c.NewRunEvent(openlineage.EventTypeComplete, uuid.New(), "job")
+			},
+			want: []string{"failed http COMPLETE status_400"},
+		},
+		{
+			name:     "disabled",
+			disabled: true,
+			event: func(c *openlineage.Client) openlineage.Emittable {
+				return c.NewRunEvent(openlineage.EventTypeStart, uuid.New(), "job")
+			},
+			want: []string{"dropped disabled"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := lineagetest.NewServer()
+			defer srv.Close()
+
+			for _, f := range tt.faults {
+				srv.InjectFault(f)
+			}
+
+			rec := &fakeRecorder{}
+			client, err := openlineage.NewClient(openlineage.ClientConfig{
+				Disabled: tt.disabled,
+				Transport: transport.Config{
+					Type: transport.TransportTypeHTTP,
+					HTTP: &transport.HTTPConfig{URL: srv.URL},
+				},
+			}, openlineage.WithMetrics(rec))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_ = client.Emit(context.Background(), tt.event(client))
+
+			if diff := deep.Equal(tt.want, rec.measurements); diff != nil {
+				t.Errorf("differences found:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Client_Metrics_NoTransport(t *testing.T) {
+	rec := &fakeRecorder{}
+	client := openlineage.NewClientWithTransport(openlineage.ClientConfig{
+		Rules: []openlineage.Rule{{Job: "job", Transport: "internal"}},
+	}, nil, openlineage.WithMetrics(rec))
+
+	if err := client.Emit(context.Background(), client.NewRunEvent(openlineage.EventTypeStart, uuid.New(), "job")); err == nil {
+		t.Fatal("emit to a missing transport succeeded")
+	}
+
+	want := []string{"failed internal START " + metrics.FailedNoTransport}
+	if diff := deep.Equal(want, rec.measurements); diff != nil {
+		t.Errorf("differences found:\n%s", diff)
+	}
+}
@@ -0,0 +1,156 @@
+package facets
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BaseFacet contains the fields every facet is required to have.
+// It can be embedded in custom facets.
+type BaseFacet struct {
+	Producer  string `json:"_producer"`  // URI identifying the producer of this metadata. For example this could be a git url with a; given tag or sha
+	SchemaURL string `json:"_schemaURL"` // The JSON Pointer (https://tools.ietf.org/html/rfc6901) URL to the corresponding version; of the schema definition for this facet
+}
+
+// NewBaseFacet creates a BaseFacet for a facet with the supplied schema URL.
+func NewBaseFacet(schemaURL string) BaseFacet {
+	return BaseFacet{
+		Producer:  "openlineage-go",
+		SchemaURL: schemaURL,
+	}
+}
+
+// CustomFacet is a facet that is not part of the OpenLineage specification.
+// When applied, Facet is stored under Name in the Custom field of the facets struct.
+type CustomFacet[T FacetTypes] struct {
+	Name  string
+	Facet any
+}
+
+// Apply implements Facet.
+func (c *CustomFacet[T]) Apply(facets **T) {
+	if *facets == nil {
+		*facets = new(T)
+	}
+
+	var custom *map[string]any
+	switch f := any(*facets).(type) {
+	case *RunFacets:
+		custom = &f.Custom
+	case *JobFacets:
+		custom = &f.Custom
+	case *DatasetFacets:
+		custom = &f.Custom
+	case *InputDatasetFacets:
+		custom = &f.Custom
+	case *OutputDatasetFacets:
+		custom = &f.Custom
+	default:
+		// unreachable for the types in FacetTypes, all of which have a Custom field
+		return
+	}
+
+	if *custom == nil {
+		*custom = make(map[string]any)
+	}
+
+	(*custom)[c.Name] = c.Facet
+}
+
+// NewCustomRunFacet creates a custom RunFacet.
+func NewCustomRunFacet(name string, facet any) *CustomFacet[RunFacets] {
+	return &CustomFacet[RunFacets]{Name: name, Facet: facet}
+}
+
+// NewCustomJobFacet creates a custom JobFacet.
+func NewCustomJobFacet(name string, facet any) *CustomFacet[JobFacets] {
+	return &CustomFacet[JobFacets]{Name: name, Facet: facet}
+}
+
+// NewCustomDatasetFacet creates a custom DatasetFacet.
+func NewCustomDatasetFacet(name string, facet any) *CustomFacet[DatasetFacets] {
+	return &CustomFacet[DatasetFacets]{Name: name, Facet: facet}
+}
+
+// NewCustomInputDatasetFacet creates a custom InputDatasetFacet.
+func NewCustomInputDatasetFacet(name string, facet any) *CustomFacet[InputDatasetFacets] {
+	return &CustomFacet[InputDatasetFacets]{Name: name, Facet: facet}
+}
+
+// NewCustomOutputDatasetFacet creates a custom OutputDatasetFacet.
+func NewCustomOutputDatasetFacet(name string, facet any) *CustomFacet[OutputDatasetFacets] {
+	return &CustomFacet[OutputDatasetFacets]{Name: name, Facet: facet}
+}
+
+// marshalWithCustom marshals v, which must marshal to a JSON object, and adds the entries of custom to it.
+// Facets defined in the specification take precedence over custom facets with the same name.
+// The fields of v are merged as raw JSON, so that numbers keep their precision.
+func marshalWithCustom(v any, custom map[string]any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(custom) == 0 {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for name, facet := range custom {
+		if _, exists := fields[name]; exists {
+			continue
+		}
+
+		raw, err := json.Marshal(facet)
+		if err != nil {
+			return nil, fmt.Errorf("marshal custom facet %s: %w", name, err)
+		}
+
+		fields[name] = raw
+	}
+
+	return json.Marshal(fields)
+}
+
+// unmarshalWithCustom unmarshals data into v, which must be a pointer to a struct.
+// Entries in data not matching a field of v are stored in custom as [json.RawMessage].
+func unmarshalWithCustom(data []byte, v any, custom *map[string]any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(v).Elem())
+	for name, raw := range fields {
+		if known[name] {
+			continue
+		}
+
+		if *custom == nil {
+			*custom = make(map[string]any)
+		}
+
+		(*custom)[name] = raw
+	}
+
+	return nil
+}
+
+// jsonFieldNames returns the JSON names of the fields of struct type t.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+
+	return names
+}
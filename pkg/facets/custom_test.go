@@ -0,0 +1,92 @@
+package facets_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
+)
+
+type testFacet struct {
+	facets.BaseFacet
+
+	Value string `json:"value"`
+}
+
+func Test_CustomFacet_RoundTrip(t *testing.T) {
+	var runFacets *facets.RunFacets
+
+	facets.NewNominalTime("2024-01-01T00:00:00Z").Apply(&runFacets)
+	facets.NewCustomRunFacet("test", testFacet{
+		BaseFacet: facets.NewBaseFacet("https://example.com/TestRunFacet.json"),
+		Value:     "foo",
+	}).Apply(&runFacets)
+
+	data, err := json.Marshal(runFacets)
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("unmarshal into map: %s", err)
+	}
+
+	for _, name := range []string{"nominalTime", "test"} {
+		if _, ok := fields[name]; !ok {
+			t.Errorf("facet %q missing from %s", name, data)
+		}
+	}
+
+	var got facets.RunFacets
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %s", err)
+	}
+
+	if got.NominalTime == nil || got.NominalTime.NominalStartTime != "2024-01-01T00:00:00Z" {
+		t.Errorf("nominalTime not decoded: %+v", got.NominalTime)
+	}
+
+	raw, ok := got.Custom["test"].(json.RawMessage)
+	if !ok {
+		t.Fatalf("custom facet not decoded as json.RawMessage: %#v", got.Custom)
+	}
+
+	var decoded testFacet
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal custom facet: %s", err)
+	}
+
+	if decoded.Value != "foo" || decoded.Producer != "openlineage-go" {
+		t.Errorf("custom facet = %+v", decoded)
+	}
+
+	if _, ok := got.Custom["nominalTime"]; ok {
+		t.Error("facet from specification stored as custom facet")
+	}
+}
+
+func Test_CustomFacet_LargeNumbers(t *testing.T) {
+	var outputFacets *facets.OutputDatasetFacets
+
+	rowCount := int64(1<<53 + 1)
+	stats := facets.NewOutputStatistics()
+	stats.RowCount = &rowCount
+
+	stats.Apply(&outputFacets)
+	facets.NewCustomOutputDatasetFacet("test", testFacet{Value: "foo"}).Apply(&outputFacets)
+
+	data, err := json.Marshal(outputFacets)
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+
+	var got facets.OutputDatasetFacets
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %s", err)
+	}
+
+	if got.OutputStatistics == nil || got.OutputStatistics.RowCount == nil || *got.OutputStatistics.RowCount != rowCount {
+		t.Errorf("rowCount not preserved in %s", data)
+	}
+}
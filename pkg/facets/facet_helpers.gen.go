@@ -2,10 +2,36 @@ package facets
 
 type InputDatasetFacets struct {
 	DataQualityMetrics *DataQualityMetrics `json:"dataQualityMetrics,omitempty"`
+
+	// Custom contains facets that are not part of the OpenLineage specification, keyed by name.
+	Custom map[string]any `json:"-"`
+}
+
+func (f InputDatasetFacets) MarshalJSON() ([]byte, error) {
+	type plain InputDatasetFacets
+	return marshalWithCustom(plain(f), f.Custom)
+}
+
+func (f *InputDatasetFacets) UnmarshalJSON(data []byte) error {
+	type plain InputDatasetFacets
+	return unmarshalWithCustom(data, (*plain)(f), &f.Custom)
 }
 
 type OutputDatasetFacets struct {
 	OutputStatistics *OutputStatistics `json:"outputStatistics,omitempty"`
+
+	// Custom contains facets that are not part of the OpenLineage specification, keyed by name.
+	Custom map[string]any `json:"-"`
+}
+
+func (f OutputDatasetFacets) MarshalJSON() ([]byte, error) {
+	type plain OutputDatasetFacets
+	return marshalWithCustom(plain(f), f.Custom)
+}
+
+func (f *OutputDatasetFacets) UnmarshalJSON(data []byte) error {
+	type plain OutputDatasetFacets
+	return unmarshalWithCustom(data, (*plain)(f), &f.Custom)
 }
 
 type DatasetFacets struct {
@@ -19,6 +45,19 @@ type DatasetFacets struct {
 	Schema                *Schema                `json:"schema,omitempty"`
 	Storage               *Storage               `json:"storage,omitempty"`
 	Symlinks              *Symlinks              `json:"symlinks,omitempty"`
+
+	// Custom contains facets that are not part of the OpenLineage specification, keyed by name.
+	Custom map[string]any `json:"-"`
+}
+
+func (f DatasetFacets) MarshalJSON() ([]byte, error) {
+	type plain DatasetFacets
+	return marshalWithCustom(plain(f), f.Custom)
+}
+
+func (f *DatasetFacets) UnmarshalJSON(data []byte) error {
+	type plain DatasetFacets
+	return unmarshalWithCustom(data, (*plain)(f), &f.Custom)
 }
 
 type JobFacets struct {
@@ -28,6 +67,19 @@ type JobFacets struct {
 	SourceCode         *SourceCode         `json:"sourceCode,omitempty"`
 	SourceCodeLocation *SourceCodeLocation `json:"sourceCodeLocation,omitempty"`
 	SQL                *SQL                `json:"sql,omitempty"`
+
+	// Custom contains facets that are not part of the OpenLineage specification, keyed by name.
+	Custom map[string]any `json:"-"`
+}
+
+func (f JobFacets) MarshalJSON() ([]byte, error) {
+	type plain JobFacets
+	return marshalWithCustom(plain(f), f.Custom)
+}
+
+func (f *JobFacets) UnmarshalJSON(data []byte) error {
+	type plain JobFacets
+	return unmarshalWithCustom(data, (*plain)(f), &f.Custom)
 }
 
 type RunFacets struct {
@@ -37,6 +89,19 @@ type RunFacets struct {
 	NominalTime      *NominalTime      `json:"nominalTime,omitempty"`
 	Parent           *Parent           `json:"parent,omitempty"`
 	ProcessingEngine *ProcessingEngine `json:"processing_engine,omitempty"`
+
+	// Custom contains facets that are not part of the OpenLineage specification, keyed by name.
+	Custom map[string]any `json:"-"`
+}
+
+func (f RunFacets) MarshalJSON() ([]byte, error) {
+	type plain RunFacets
+	return marshalWithCustom(plain(f), f.Custom)
+}
+
+func (f *RunFacets) UnmarshalJSON(data []byte) error {
+	type plain RunFacets
+	return unmarshalWithCustom(data, (*plain)(f), &f.Custom)
 }
 
 var _ InputDatasetFacet = (*DataQualityMetrics)(nil)
@@ -543,11 +608,16 @@ func NewParent(
 ) *Parent {
 	return &Parent{
 		Producer:  "openlineage-go",
-		SchemaURL: "https://openlineage.io/spec/facets/1-0-1/ParentRunFacet.json",
+		SchemaURL: "https://openlineage.io/spec/facets/1-1-0/ParentRunFacet.json",
 		Job:       job,
 		Run:       run,
 	}
 }
+func (x *Parent) WithRoot(root Root) *Parent {
+	x.Root = &root
+
+	return x
+}
 
 func NewProcessingEngine(
 	version string,
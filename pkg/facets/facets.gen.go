@@ -239,6 +239,7 @@ type Parent struct {
 	Producer  string `json:"_producer"`  // URI identifying the producer of this metadata. For example this could be a git url with a; given tag or sha
 	SchemaURL string `json:"_schemaURL"` // The JSON Pointer (https://tools.ietf.org/html/rfc6901) URL to the corresponding version; of the schema definition for this facet
 	Job       Job    `json:"job"`
+	Root      *Root  `json:"root,omitempty"`
 	Run       Run    `json:"run"`
 }
 
@@ -251,6 +252,11 @@ type Run struct {
 	RunID string `json:"runId"` // The globally unique ID of the run associated with the job.
 }
 
+type Root struct {
+	Job Job `json:"job"`
+	Run Run `json:"run"`
+}
+
 // A Run Facet
 //
 // all fields of the base facet are prefixed with _ to avoid name conflicts in facets
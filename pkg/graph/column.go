@@ -0,0 +1,211 @@
+package graph
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
+)
+
+// ColumnID identifies a field of a dataset.
+type ColumnID struct {
+	Namespace string
+	Name      string
+	Field     string
+}
+
+// Dataset returns the NodeID of the dataset containing the column.
+func (c ColumnID) Dataset() NodeID {
+	return DatasetID(c.Namespace, c.Name)
+}
+
+func (c ColumnID) String() string {
+	return fmt.Sprintf("%s/%s.%s", c.Namespace, c.Name, c.Field)
+}
+
+func compareColumnIDs(a, b ColumnID) int {
+	return cmp.Or(
+		cmp.Compare(a.Namespace, b.Namespace),
+		cmp.Compare(a.Name, b.Name),
+		cmp.Compare(a.Field, b.Field),
+	)
+}
+
+// ColumnEdge is a directed edge from an input field to an output field derived from it.
+type ColumnEdge struct {
+	From ColumnID
+	To   ColumnID
+
+	// Job is the job that produced the output field, or the zero NodeID if the lineage came from a dataset event.
+	Job NodeID
+
+	// Transformations are the transformations applied to From to produce To.
+	Transformations []facets.Transformation
+
+	// TransformationType is the transformation type of the output field, e.g. IDENTITY or MASKED.
+	// It is deprecated in the specification in favour of Transformations.
+	TransformationType string
+}
+
+// Masking reports whether the edge includes a transformation that masks the data.
+func (e ColumnEdge) Masking() bool {
+	if e.TransformationType == "MASKED" {
+		return true
+	}
+
+	for _, t := range e.Transformations {
+		if t.Masking != nil && *t.Masking {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ColumnDependency is a column reached by traversing column-level lineage.
+type ColumnDependency struct {
+	Column ColumnID
+
+	// Distance is the number of edges on the shortest path to the column.
+	Distance int
+
+	// Masked reports whether every path to the column, within the traversal's depth, includes a masking transformation.
+	// A column with Masked set to false can be reached without masking the data.
+	Masked bool
+}
+
+func (g *Graph) addColumnLineage(job NodeID, namespace, name string, datasetFacets *facets.DatasetFacets) {
+	if datasetFacets == nil || datasetFacets.ColumnLineage == nil {
+		return
+	}
+
+	for field, lineage := range datasetFacets.ColumnLineage.Fields {
+		to := ColumnID{Namespace: namespace, Name: name, Field: field}
+
+		for _, in := range lineage.InputFields {
+			edge := &ColumnEdge{
+				From:            ColumnID{Namespace: in.Namespace, Name: in.Name, Field: in.Field},
+				To:              to,
+				Job:             job,
+				Transformations: in.Transformations,
+			}
+
+			if lineage.TransformationType != nil {
+				edge.TransformationType = *lineage.TransformationType
+			}
+
+			g.addColumnEdge(edge)
+		}
+	}
+}
+
+// addColumnEdge adds edge, replacing an existing edge between the same columns.
+func (g *Graph) addColumnEdge(edge *ColumnEdge) {
+	if g.columnDownstream[edge.From] == nil {
+		g.columnDownstream[edge.From] = make(map[ColumnID]*ColumnEdge)
+	}
+	g.columnDownstream[edge.From][edge.To] = edge
+
+	if g.columnUpstream[edge.To] == nil {
+		g.columnUpstream[edge.To] = make(map[ColumnID]*ColumnEdge)
+	}
+	g.columnUpstream[edge.To][edge.From] = edge
+}
+
+// ColumnEdges returns all column-level edges, sorted by source and target.
+// If several events describe the lineage between the same columns, the one added last is used.
+func (g *Graph) ColumnEdges() []ColumnEdge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.sortedColumnEdges()
+}
+
+func (g *Graph) sortedColumnEdges() []ColumnEdge {
+	var edges []ColumnEdge
+	for _, targets := range g.columnDownstream {
+		for _, e := range targets {
+			edges = append(edges, *e)
+		}
+	}
+
+	slices.SortFunc(edges, func(a, b ColumnEdge) int {
+		return cmp.Or(compareColumnIDs(a.From, b.From), compareColumnIDs(a.To, b.To))
+	})
+
+	return edges
+}
+
+// DownstreamColumns returns the columns derived from col, directly or transitively,
+// ordered by distance and then by ID.
+// If depth is greater than zero, only columns up to depth edges away are returned.
+func (g *Graph) DownstreamColumns(col ColumnID, depth int) []ColumnDependency {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return traverseColumns(g.columnDownstream, col, depth)
+}
+
+// UpstreamColumns returns the columns col is derived from, directly or transitively,
+// ordered by distance and then by ID.
+// If depth is greater than zero, only columns up to depth edges away are returned.
+func (g *Graph) UpstreamColumns(col ColumnID, depth int) []ColumnDependency {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return traverseColumns(g.columnUpstream, col, depth)
+}
+
+// traverseColumns performs a breadth-first search from start along edges,
+// tracking whether each column can be reached without passing a masking transformation.
+func traverseColumns(edges map[ColumnID]map[ColumnID]*ColumnEdge, start ColumnID, depth int) []ColumnDependency {
+	type state struct {
+		column ColumnID
+		masked bool
+	}
+
+	visited := map[state]bool{}
+	found := map[ColumnID]*ColumnDependency{}
+
+	level := []state{{column: start}}
+	for d := 1; len(level) > 0 && (depth <= 0 || d <= depth); d++ {
+		var next []state
+		for _, s := range level {
+			for to, e := range edges[s.column] {
+				n := state{column: to, masked: s.masked || e.Masking()}
+
+				// once a column is reached unmasked, masked paths through it add nothing
+				if visited[n] || (n.masked && visited[state{column: to}]) {
+					continue
+				}
+
+				visited[n] = true
+				next = append(next, n)
+
+				dep, ok := found[to]
+				if !ok {
+					dep = &ColumnDependency{Column: to, Distance: d, Masked: true}
+					found[to] = dep
+				}
+
+				if !n.masked {
+					dep.Masked = false
+				}
+			}
+		}
+
+		level = next
+	}
+
+	result := make([]ColumnDependency, 0, len(found))
+	for _, dep := range found {
+		result = append(result, *dep)
+	}
+
+	slices.SortFunc(result, func(a, b ColumnDependency) int {
+		return cmp.Or(cmp.Compare(a.Distance, b.Distance), compareColumnIDs(a.Column, b.Column))
+	})
+
+	return result
+}
@@ -0,0 +1,167 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
+	"github.com/ThijsKoot/openlineage-go/pkg/graph"
+	"github.com/go-test/deep"
+	"github.com/google/uuid"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func identity() facets.Transformation {
+	return facets.Transformation{Type: "DIRECT", Subtype: ptr("IDENTITY")}
+}
+
+func hash() facets.Transformation {
+	return facets.Transformation{Type: "DIRECT", Subtype: ptr("TRANSFORMATION"), Masking: ptr(true)}
+}
+
+func inputField(dataset, field string, transformations ...facets.Transformation) facets.InputField {
+	return facets.InputField{Namespace: ns, Name: dataset, Field: field, Transformations: transformations}
+}
+
+// columnEvent creates an event for job writing to output, with the supplied column lineage.
+func columnEvent(job, output string, fields map[string]facets.Field) openlineage.Event {
+	out := openlineage.NewOutputElement(output, ns).
+		WithFacets(facets.NewColumnLineage().WithFields(fields))
+
+	return openlineage.NewNamespacedRunEvent(openlineage.EventTypeComplete, uuid.New(), job, ns).
+		WithOutputs(out).
+		AsEmittable()
+}
+
+func column(dataset, field string) graph.ColumnID {
+	return graph.ColumnID{Namespace: ns, Name: dataset, Field: field}
+}
+
+func Test_ColumnLineage(t *testing.T) {
+	// users.email -> staging.email -> mart.email_hash (masked)
+	//                              -> export.email
+	g, err := graph.FromEvents(
+		columnEvent("stage", "staging", map[string]facets.Field{
+			"email": {InputFields: []facets.InputField{inputField("users", "email", identity())}},
+			"id":    {InputFields: []facets.InputField{inputField("users", "id", identity())}},
+		}),
+		columnEvent("anonymize", "mart", map[string]facets.Field{
+			"email_hash": {InputFields: []facets.InputField{inputField("staging", "email", hash())}},
+		}),
+		columnEvent("export", "export", map[string]facets.Field{
+			"email": {InputFields: []facets.InputField{inputField("staging", "email")}},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		got      []graph.ColumnDependency
+		expected []graph.ColumnDependency
+	}{
+		{
+			name: "downstream",
+			got:  g.DownstreamColumns(column("users", "email"), 0),
+			expected: []graph.ColumnDependency{
+				{Column: column("staging", "email"), Distance: 1},
+				{Column: column("export", "email"), Distance: 2},
+				{Column: column("mart", "email_hash"), Distance: 2, Masked: true},
+			},
+		},
+		{
+			name: "downstream-depth",
+			got:  g.DownstreamColumns(column("users", "email"), 1),
+			expected: []graph.ColumnDependency{
+				{Column: column("staging", "email"), Distance: 1},
+			},
+		},
+		{
+			name: "upstream",
+			got:  g.UpstreamColumns(column("mart", "email_hash"), 0),
+			expected: []graph.ColumnDependency{
+				{Column: column("staging", "email"), Distance: 1, Masked: true},
+				{Column: column("users", "email"), Distance: 2, Masked: true},
+			},
+		},
+		{
+			name:     "unrelated-column",
+			got:      g.DownstreamColumns(column("users", "id"), 0),
+			expected: []graph.ColumnDependency{{Column: column("staging", "id"), Distance: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(tt.got, tt.expected); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_ColumnLineage_UnmaskedPath(t *testing.T) {
+	g, err := graph.FromEvents(
+		columnEvent("anonymize", "mart", map[string]facets.Field{
+			"email_hash": {
+				InputFields:        []facets.InputField{inputField("users", "email")},
+				TransformationType: ptr("MASKED"),
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deps := g.DownstreamColumns(column("users", "email"), 0)
+	if len(deps) != 1 || !deps[0].Masked {
+		t.Fatalf("expected a masked dependency, got %+v", deps)
+	}
+
+	// a later run of another job copies the email into the mart through an intermediate table
+	err = g.Add(
+		columnEvent("copy", "tmp", map[string]facets.Field{
+			"email": {InputFields: []facets.InputField{inputField("users", "email")}},
+		}),
+		columnEvent("leak", "mart", map[string]facets.Field{
+			"email_hash": {InputFields: []facets.InputField{inputField("tmp", "email")}},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []graph.ColumnDependency{
+		{Column: column("mart", "email_hash"), Distance: 1},
+		{Column: column("tmp", "email"), Distance: 1},
+	}
+
+	if diff := deep.Equal(g.DownstreamColumns(column("users", "email"), 0), expected); diff != nil {
+		t.Error(diff)
+	}
+
+	edges := g.ColumnEdges()
+	if len(edges) != 3 || edges[0].Job != graph.JobID(ns, "leak") {
+		t.Errorf("unexpected edges: %+v", edges)
+	}
+}
+
+func Test_ColumnLineage_DatasetEvent(t *testing.T) {
+	event := openlineage.NewDatasetEvent("mart", ns, facets.NewColumnLineage().WithFields(map[string]facets.Field{
+		"email": {InputFields: []facets.InputField{inputField("users", "email")}},
+	}))
+
+	g, err := graph.FromEvents(event.AsEmittable())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edges := g.ColumnEdges()
+	if len(edges) != 1 || edges[0].Job != (graph.NodeID{}) || edges[0].To != column("mart", "email") {
+		t.Errorf("unexpected edges: %+v", edges)
+	}
+}
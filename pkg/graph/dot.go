@@ -0,0 +1,49 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDOT writes the graph to w in the Graphviz DOT language.
+// Jobs are drawn as boxes coloured by the state of their latest run, datasets as cylinders,
+// and column-level lineage as dashed edges between datasets.
+func (g *Graph) WriteDOT(w io.Writer, opts ...ExportOption) error {
+	m := g.exportModel(opts)
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "digraph lineage {")
+	fmt.Fprintln(bw, "\trankdir=LR;")
+	fmt.Fprintln(bw, `	node [fontname="Helvetica", style=filled];`)
+	fmt.Fprintln(bw, `	edge [fontname="Helvetica", fontsize=10];`)
+
+	for _, n := range m.nodes {
+		shape := "box"
+		if n.id.Kind == NodeKindDataset {
+			shape = "cylinder"
+		}
+
+		fmt.Fprintf(bw, "\t%s [label=%s, tooltip=%s, shape=%s, fillcolor=%s];\n",
+			n.key, dotQuote(n.id.Name), dotQuote(n.id.String()), shape, dotQuote(n.color()))
+	}
+
+	for _, e := range m.edges {
+		if !e.column {
+			fmt.Fprintf(bw, "\t%s -> %s;\n", e.from, e.to)
+			continue
+		}
+
+		fmt.Fprintf(bw, "\t%s -> %s [label=%s, style=dashed, color=%s, fontcolor=%s];\n",
+			e.from, e.to, dotQuote(e.label), dotQuote(columnColor), dotQuote(columnColor))
+	}
+
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
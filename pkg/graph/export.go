@@ -0,0 +1,162 @@
+package graph
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/ThijsKoot/openlineage-go"
+)
+
+// ExportOption configures the output of [Graph.WriteDOT], [Graph.WriteMermaid] and [Graph.WriteGraphML].
+type ExportOption func(*exportConfig)
+
+type exportConfig struct {
+	collapseColumns bool
+}
+
+// WithCollapsedColumns replaces the column-level edges between two datasets with a single edge
+// labelled with the number of columns.
+func WithCollapsedColumns() ExportOption {
+	return func(c *exportConfig) {
+		c.collapseColumns = true
+	}
+}
+
+// stateColors are the fill colours of jobs, based on the state of their latest run.
+var stateColors = map[openlineage.EventType]string{
+	openlineage.EventTypeStart:    "#9ecae1",
+	openlineage.EventTypeRunning:  "#9ecae1",
+	openlineage.EventTypeComplete: "#a1d99b",
+	openlineage.EventTypeFail:     "#fc9272",
+	openlineage.EventTypeAbort:    "#fdd0a2",
+}
+
+const (
+	jobColor     = "#ffffff"
+	datasetColor = "#f0f0f0"
+	columnColor  = "#969696"
+)
+
+type exportNode struct {
+	key string
+	id  NodeID
+
+	// state is the state of the latest run of a job, if any
+	state openlineage.EventType
+}
+
+func (n exportNode) color() string {
+	if n.id.Kind == NodeKindDataset {
+		return datasetColor
+	}
+
+	if c, ok := stateColors[n.state]; ok {
+		return c
+	}
+
+	return jobColor
+}
+
+type exportEdge struct {
+	from, to string
+
+	// column is set for edges derived from column-level lineage
+	column bool
+	label  string
+}
+
+// exportModel is the format-independent representation of a Graph that exporters render.
+type exportModel struct {
+	nodes []exportNode
+	edges []exportEdge
+}
+
+func (g *Graph) exportModel(opts []ExportOption) exportModel {
+	var cfg exportConfig
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	// a single read lock keeps nodes, edges and runs consistent with each other
+	// when events are added concurrently
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	nodes := g.sortedNodes()
+	columnEdges := g.sortedColumnEdges()
+
+	// column lineage can reference datasets that are not an input or output of any job
+	for _, e := range columnEdges {
+		for _, id := range []NodeID{e.From.Dataset(), e.To.Dataset()} {
+			if _, found := slices.BinarySearchFunc(nodes, id, compareNodeIDs); !found {
+				nodes = append(nodes, id)
+				slices.SortFunc(nodes, compareNodeIDs)
+			}
+		}
+	}
+
+	var m exportModel
+	keys := make(map[NodeID]string, len(nodes))
+	for i, id := range nodes {
+		n := exportNode{key: fmt.Sprintf("n%d", i), id: id}
+		if r, ok := g.latestRun(id); ok {
+			n.state = r.State
+		}
+
+		keys[id] = n.key
+		m.nodes = append(m.nodes, n)
+	}
+
+	for _, e := range g.sortedEdges() {
+		m.edges = append(m.edges, exportEdge{from: keys[e.From], to: keys[e.To]})
+	}
+
+	if !cfg.collapseColumns {
+		for _, e := range columnEdges {
+			label := fmt.Sprintf("%s → %s", e.From.Field, e.To.Field)
+			if e.Masking() {
+				label += " (masked)"
+			}
+
+			m.edges = append(m.edges, exportEdge{
+				from:   keys[e.From.Dataset()],
+				to:     keys[e.To.Dataset()],
+				column: true,
+				label:  label,
+			})
+		}
+
+		return m
+	}
+
+	counts := map[Edge]int{}
+	for _, e := range columnEdges {
+		counts[Edge{From: e.From.Dataset(), To: e.To.Dataset()}]++
+	}
+
+	collapsed := make([]Edge, 0, len(counts))
+	for e := range counts {
+		collapsed = append(collapsed, e)
+	}
+
+	slices.SortFunc(collapsed, func(a, b Edge) int {
+		return cmp.Or(compareNodeIDs(a.From, b.From), compareNodeIDs(a.To, b.To))
+	})
+
+	for _, e := range collapsed {
+		label := fmt.Sprintf("%d columns", counts[e])
+		if counts[e] == 1 {
+			label = "1 column"
+		}
+
+		m.edges = append(m.edges, exportEdge{
+			from:   keys[e.From],
+			to:     keys[e.To],
+			column: true,
+			label:  label,
+		})
+	}
+
+	return m
+}
@@ -0,0 +1,87 @@
+package graph_test
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
+	"github.com/ThijsKoot/openlineage-go/pkg/graph"
+	"github.com/google/uuid"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func newExportGraph(t *testing.T) *graph.Graph {
+	t.Helper()
+
+	transformRun := uuid.New()
+	columns := openlineage.NewOutputElement("mart", ns).
+		WithFacets(facets.NewColumnLineage().WithFields(map[string]facets.Field{
+			"id":         {InputFields: []facets.InputField{inputField("staged", "id", identity())}},
+			"email_hash": {InputFields: []facets.InputField{inputField("staged", "email", hash())}},
+		}))
+
+	transformFail := openlineage.NewNamespacedRunEvent(openlineage.EventTypeFail, transformRun, "transform", ns).
+		WithInputs(openlineage.NewInputElement("staged", ns)).
+		WithOutputs(columns).
+		AsEmittable()
+	transformFail.EventTime = start.Add(2 * time.Minute).Format(time.RFC3339Nano)
+
+	g, err := graph.FromEvents(
+		runEvent(openlineage.EventTypeComplete, uuid.New(), "ingest", 0, []string{"raw"}, []string{"staged"}),
+		runEvent(openlineage.EventTypeStart, transformRun, "transform", time.Minute, []string{"staged"}, nil),
+		transformFail,
+		runEvent(openlineage.EventTypeStart, uuid.New(), "report \"daily\"", 3*time.Minute, []string{"mart"}, nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g
+}
+
+func Test_Export(t *testing.T) {
+	g := newExportGraph(t)
+
+	tests := []struct {
+		golden string
+		write  func(io.Writer, ...graph.ExportOption) error
+		opts   []graph.ExportOption
+	}{
+		{golden: "lineage.dot", write: g.WriteDOT},
+		{golden: "lineage-collapsed.dot", write: g.WriteDOT, opts: []graph.ExportOption{graph.WithCollapsedColumns()}},
+		{golden: "lineage.mmd", write: g.WriteMermaid},
+		{golden: "lineage.graphml", write: g.WriteGraphML},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.write(&buf, tt.opts...); err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expected, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(buf.Bytes(), expected) {
+				t.Errorf("output does not match %s, run with -update to update it:\n%s", path, buf.String())
+			}
+		})
+	}
+}
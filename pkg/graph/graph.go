@@ -0,0 +1,337 @@
+// Package graph builds an in-memory lineage graph from OpenLineage events.
+//
+// Jobs and datasets are the nodes of the graph. Every input of a job adds an edge from the dataset to the job,
+// and every output adds an edge from the job to the dataset. Runs are tracked per job.
+//
+// Column-level lineage is built from the ColumnLineage facets of output datasets and dataset events,
+// with an edge from every input field to the output field derived from it.
+package graph
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+)
+
+// NodeKind is the kind of entity a node represents.
+type NodeKind string
+
+const (
+	NodeKindJob     NodeKind = "job"
+	NodeKindDataset NodeKind = "dataset"
+)
+
+// NodeID identifies a job or dataset in the graph.
+type NodeID struct {
+	Kind      NodeKind
+	Namespace string
+	Name      string
+}
+
+// JobID returns the NodeID of a job.
+func JobID(namespace, name string) NodeID {
+	return NodeID{Kind: NodeKindJob, Namespace: namespace, Name: name}
+}
+
+// DatasetID returns the NodeID of a dataset.
+func DatasetID(namespace, name string) NodeID {
+	return NodeID{Kind: NodeKindDataset, Namespace: namespace, Name: name}
+}
+
+func (id NodeID) String() string {
+	return fmt.Sprintf("%s:%s/%s", id.Kind, id.Namespace, id.Name)
+}
+
+func compareNodeIDs(a, b NodeID) int {
+	return cmp.Or(
+		cmp.Compare(a.Kind, b.Kind),
+		cmp.Compare(a.Namespace, b.Namespace),
+		cmp.Compare(a.Name, b.Name),
+	)
+}
+
+// Edge is a directed edge in the graph, pointing in the direction data flows.
+type Edge struct {
+	From NodeID
+	To   NodeID
+}
+
+// Run summarizes the events of a single run.
+type Run struct {
+	RunID string
+	Job   NodeID
+
+	// ParentRunID is the ID of the run's parent, if it has a parent facet.
+	ParentRunID string
+
+	// State is the type of the run's most recent event that is not OTHER.
+	// If the run only has OTHER events, State is OTHER.
+	State openlineage.EventType
+
+	// FirstEventTime and LastEventTime are the times of the earliest and latest events of the run.
+	FirstEventTime time.Time
+	LastEventTime  time.Time
+
+	// stateTime is the time of the event that determined State
+	stateTime time.Time
+}
+
+// Graph is an in-memory lineage graph. It is safe for concurrent use.
+type Graph struct {
+	mu sync.RWMutex
+
+	nodes      map[NodeID]struct{}
+	downstream map[NodeID]map[NodeID]struct{}
+	upstream   map[NodeID]map[NodeID]struct{}
+
+	columnDownstream map[ColumnID]map[ColumnID]*ColumnEdge
+	columnUpstream   map[ColumnID]map[ColumnID]*ColumnEdge
+
+	runs    map[string]*Run
+	jobRuns map[NodeID][]*Run
+}
+
+// New creates an empty Graph.
+func New() *Graph {
+	return &Graph{
+		nodes:      make(map[NodeID]struct{}),
+		downstream: make(map[NodeID]map[NodeID]struct{}),
+		upstream:   make(map[NodeID]map[NodeID]struct{}),
+
+		columnDownstream: make(map[ColumnID]map[ColumnID]*ColumnEdge),
+		columnUpstream:   make(map[ColumnID]map[ColumnID]*ColumnEdge),
+
+		runs:    make(map[string]*Run),
+		jobRuns: make(map[NodeID][]*Run),
+	}
+}
+
+// FromEvents creates a Graph and adds events to it.
+// Like [Graph.Add], it skips invalid events: the Graph of the valid events is returned
+// along with an error reporting the invalid ones.
+func FromEvents(events ...openlineage.Event) (*Graph, error) {
+	g := New()
+	err := g.Add(events...)
+
+	return g, err
+}
+
+// Add adds the jobs, datasets and runs in events to the graph.
+// Events are validated using [openlineage.ValidateEvent].
+// Invalid events are skipped, and reported together in the returned error.
+func (g *Graph) Add(events ...openlineage.Event) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var errs []error
+	for i, e := range events {
+		if err := openlineage.ValidateEvent(e); err != nil {
+			errs = append(errs, fmt.Errorf("event %d: %w", i, err))
+			continue
+		}
+
+		g.add(e)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid events: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func (g *Graph) add(e openlineage.Event) {
+	if e.Dataset != nil {
+		g.addNode(DatasetID(e.Dataset.Namespace, e.Dataset.Name))
+		g.addColumnLineage(NodeID{}, e.Dataset.Namespace, e.Dataset.Name, e.Dataset.Facets)
+	}
+
+	if e.Job == nil {
+		return
+	}
+
+	job := JobID(e.Job.Namespace, e.Job.Name)
+	g.addNode(job)
+
+	for _, in := range e.Inputs {
+		g.addEdge(DatasetID(in.Namespace, in.Name), job)
+	}
+
+	for _, out := range e.Outputs {
+		g.addEdge(job, DatasetID(out.Namespace, out.Name))
+		g.addColumnLineage(job, out.Namespace, out.Name, out.Facets)
+	}
+
+	if e.Run != nil {
+		g.addRunEvent(job, e)
+	}
+}
+
+func (g *Graph) addNode(id NodeID) {
+	g.nodes[id] = struct{}{}
+}
+
+func (g *Graph) addEdge(from, to NodeID) {
+	g.addNode(from)
+	g.addNode(to)
+
+	if g.downstream[from] == nil {
+		g.downstream[from] = make(map[NodeID]struct{})
+	}
+	g.downstream[from][to] = struct{}{}
+
+	if g.upstream[to] == nil {
+		g.upstream[to] = make(map[NodeID]struct{})
+	}
+	g.upstream[to][from] = struct{}{}
+}
+
+func (g *Graph) addRunEvent(job NodeID, e openlineage.Event) {
+	// the event has been validated, so its time is known to parse
+	eventTime, _ := time.Parse(time.RFC3339Nano, e.EventTime)
+
+	r, ok := g.runs[e.Run.RunID]
+	if !ok {
+		r = &Run{
+			RunID:          e.Run.RunID,
+			Job:            job,
+			State:          openlineage.EventTypeOther,
+			FirstEventTime: eventTime,
+			LastEventTime:  eventTime,
+		}
+		g.runs[r.RunID] = r
+		g.jobRuns[job] = append(g.jobRuns[job], r)
+	}
+
+	if eventTime.Before(r.FirstEventTime) {
+		r.FirstEventTime = eventTime
+	}
+
+	if eventTime.After(r.LastEventTime) {
+		r.LastEventTime = eventTime
+	}
+
+	if e.Run.Facets != nil && e.Run.Facets.Parent != nil {
+		r.ParentRunID = e.Run.Facets.Parent.Run.RunID
+	}
+
+	// events are often emitted asynchronously, so they are ordered by time rather than by arrival
+	eventType := openlineage.EventTypeOther
+	if e.EventType != nil {
+		eventType = *e.EventType
+	}
+
+	if eventType != openlineage.EventTypeOther && (r.State == openlineage.EventTypeOther || !eventTime.Before(r.stateTime)) {
+		r.State = eventType
+		r.stateTime = eventTime
+	}
+}
+
+// Nodes returns all nodes in the graph, sorted by kind, namespace and name.
+func (g *Graph) Nodes() []NodeID {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.sortedNodes()
+}
+
+func (g *Graph) sortedNodes() []NodeID {
+	nodes := make([]NodeID, 0, len(g.nodes))
+	for id := range g.nodes {
+		nodes = append(nodes, id)
+	}
+
+	slices.SortFunc(nodes, compareNodeIDs)
+
+	return nodes
+}
+
+// HasNode reports whether id is part of the graph.
+func (g *Graph) HasNode(id NodeID) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	_, ok := g.nodes[id]
+
+	return ok
+}
+
+// Edges returns all edges in the graph, sorted by source and target.
+func (g *Graph) Edges() []Edge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.sortedEdges()
+}
+
+func (g *Graph) sortedEdges() []Edge {
+	var edges []Edge
+	for from, targets := range g.downstream {
+		for to := range targets {
+			edges = append(edges, Edge{From: from, To: to})
+		}
+	}
+
+	slices.SortFunc(edges, func(a, b Edge) int {
+		return cmp.Or(compareNodeIDs(a.From, b.From), compareNodeIDs(a.To, b.To))
+	})
+
+	return edges
+}
+
+// Run returns the run with the supplied ID.
+func (g *Graph) Run(runID string) (Run, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	r, ok := g.runs[runID]
+	if !ok {
+		return Run{}, false
+	}
+
+	return *r, true
+}
+
+// Runs returns the runs of job, ordered by the time of their first event.
+func (g *Graph) Runs(job NodeID) []Run {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.sortedRuns(job)
+}
+
+func (g *Graph) sortedRuns(job NodeID) []Run {
+	runs := make([]Run, 0, len(g.jobRuns[job]))
+	for _, r := range g.jobRuns[job] {
+		runs = append(runs, *r)
+	}
+
+	slices.SortStableFunc(runs, func(a, b Run) int {
+		return a.FirstEventTime.Compare(b.FirstEventTime)
+	})
+
+	return runs
+}
+
+// LatestRun returns the run of job that started last, based on the time of its first event.
+// If several runs started at the same time, the one added to the graph last is returned.
+func (g *Graph) LatestRun(job NodeID) (Run, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.latestRun(job)
+}
+
+func (g *Graph) latestRun(job NodeID) (Run, bool) {
+	runs := g.sortedRuns(job)
+	if len(runs) == 0 {
+		return Run{}, false
+	}
+
+	return runs[len(runs)-1], true
+}
@@ -0,0 +1,249 @@
+package graph_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
+	"github.com/ThijsKoot/openlineage-go/pkg/graph"
+	"github.com/go-test/deep"
+	"github.com/google/uuid"
+)
+
+const ns = "test"
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func runEvent(eventType openlineage.EventType, runID uuid.UUID, job string, at time.Duration, inputs []string, outputs []string) openlineage.Event {
+	event := openlineage.NewNamespacedRunEvent(eventType, runID, job, ns)
+	event.EventTime = start.Add(at).Format(time.RFC3339Nano)
+
+	for _, in := range inputs {
+		event = event.WithInputs(openlineage.NewInputElement(in, ns))
+	}
+
+	for _, out := range outputs {
+		event = event.WithOutputs(openlineage.NewOutputElement(out, ns))
+	}
+
+	return event.AsEmittable()
+}
+
+func ids(kind graph.NodeKind, names ...string) []graph.NodeID {
+	var result []graph.NodeID
+	for _, n := range names {
+		result = append(result, graph.NodeID{Kind: kind, Namespace: ns, Name: n})
+	}
+
+	return result
+}
+
+// pipeline: raw -> ingest -> staged -> transform -> (mart, audit) -> report -> dashboard
+func newPipeline(t *testing.T) *graph.Graph {
+	t.Helper()
+
+	g, err := graph.FromEvents(
+		runEvent(openlineage.EventTypeComplete, uuid.New(), "ingest", 0, []string{"raw"}, []string{"staged"}),
+		runEvent(openlineage.EventTypeComplete, uuid.New(), "transform", time.Minute, []string{"staged"}, []string{"mart", "audit"}),
+		runEvent(openlineage.EventTypeComplete, uuid.New(), "report", 2*time.Minute, []string{"mart"}, []string{"dashboard"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g
+}
+
+func Test_Traversal(t *testing.T) {
+	g := newPipeline(t)
+
+	tests := []struct {
+		name     string
+		got      []graph.NodeID
+		expected []graph.NodeID
+	}{
+		{
+			name: "downstream-unlimited",
+			got:  g.Downstream(graph.DatasetID(ns, "staged"), 0),
+			expected: []graph.NodeID{
+				graph.JobID(ns, "transform"),
+				graph.DatasetID(ns, "audit"),
+				graph.DatasetID(ns, "mart"),
+				graph.JobID(ns, "report"),
+				graph.DatasetID(ns, "dashboard"),
+			},
+		},
+		{
+			name:     "downstream-depth",
+			got:      g.Downstream(graph.DatasetID(ns, "staged"), 2),
+			expected: append(ids(graph.NodeKindJob, "transform"), ids(graph.NodeKindDataset, "audit", "mart")...),
+		},
+		{
+			name: "upstream-unlimited",
+			got:  g.Upstream(graph.JobID(ns, "report"), 0),
+			expected: []graph.NodeID{
+				graph.DatasetID(ns, "mart"),
+				graph.JobID(ns, "transform"),
+				graph.DatasetID(ns, "staged"),
+				graph.JobID(ns, "ingest"),
+				graph.DatasetID(ns, "raw"),
+			},
+		},
+		{
+			name:     "upstream-depth",
+			got:      g.Upstream(graph.JobID(ns, "report"), 1),
+			expected: ids(graph.NodeKindDataset, "mart"),
+		},
+		{
+			name:     "unknown-node",
+			got:      g.Upstream(graph.JobID(ns, "unknown"), 0),
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(tt.got, tt.expected); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func Test_Cycles(t *testing.T) {
+	g := newPipeline(t)
+	if g.HasCycles() {
+		t.Fatalf("pipeline has cycles: %v", g.Cycles())
+	}
+
+	// dedupe reads and writes staged, and audit feeds back into ingest
+	err := g.Add(
+		runEvent(openlineage.EventTypeComplete, uuid.New(), "dedupe", 0, []string{"staged"}, []string{"staged"}),
+		runEvent(openlineage.EventTypeComplete, uuid.New(), "ingest", 0, []string{"audit"}, nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]graph.NodeID{
+		{
+			graph.DatasetID(ns, "audit"),
+			graph.DatasetID(ns, "staged"),
+			graph.JobID(ns, "dedupe"),
+			graph.JobID(ns, "ingest"),
+			graph.JobID(ns, "transform"),
+		},
+	}
+
+	if diff := deep.Equal(g.Cycles(), expected); diff != nil {
+		t.Error(diff)
+	}
+
+	// traversal terminates and includes the start node through the cycle
+	downstream := g.Downstream(graph.JobID(ns, "ingest"), 0)
+	if len(downstream) != 8 {
+		t.Errorf("expected 8 downstream nodes, got %v", downstream)
+	}
+}
+
+func Test_Runs(t *testing.T) {
+	first, second, child := uuid.New(), uuid.New(), uuid.New()
+
+	childStart := runEvent(openlineage.EventTypeStart, child, "child", 3*time.Minute, nil, nil)
+	childStart.Run.Facets = &facets.RunFacets{
+		Parent: facets.NewParent(facets.Job{Name: "job", Namespace: ns}, facets.Run{RunID: second.String()}),
+	}
+
+	g, err := graph.FromEvents(
+		runEvent(openlineage.EventTypeStart, first, "job", 0, nil, nil),
+		runEvent(openlineage.EventTypeFail, first, "job", time.Minute, nil, nil),
+		// out of order: COMPLETE arrives before START, OTHER after COMPLETE
+		runEvent(openlineage.EventTypeComplete, second, "job", 5*time.Minute, nil, nil),
+		runEvent(openlineage.EventTypeStart, second, "job", 2*time.Minute, nil, nil),
+		runEvent(openlineage.EventTypeOther, second, "job", 6*time.Minute, nil, nil),
+		childStart,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, ok := g.LatestRun(graph.JobID(ns, "job"))
+	if !ok {
+		t.Fatal("no latest run")
+	}
+
+	expected := graph.Run{
+		RunID:          second.String(),
+		Job:            graph.JobID(ns, "job"),
+		State:          openlineage.EventTypeComplete,
+		FirstEventTime: start.Add(2 * time.Minute),
+		LastEventTime:  start.Add(6 * time.Minute),
+	}
+
+	if diff := deep.Equal(latest, expected); diff != nil {
+		t.Error(diff)
+	}
+
+	if runs := g.Runs(graph.JobID(ns, "job")); len(runs) != 2 || runs[0].State != openlineage.EventTypeFail {
+		t.Errorf("unexpected runs: %+v", runs)
+	}
+
+	if r, _ := g.Run(child.String()); r.ParentRunID != second.String() {
+		t.Errorf("expected parent %s, got %q", second, r.ParentRunID)
+	}
+
+	if _, ok := g.LatestRun(graph.JobID(ns, "unknown")); ok {
+		t.Error("unknown job has a latest run")
+	}
+}
+
+func Test_Add_Invalid(t *testing.T) {
+	invalid := runEvent(openlineage.EventTypeStart, uuid.New(), "job", 0, []string{"in"}, nil)
+	invalid.EventTime = "yesterday"
+
+	events := []openlineage.Event{
+		invalid,
+		runEvent(openlineage.EventTypeStart, uuid.New(), "other", 0, []string{"in"}, nil),
+	}
+
+	tests := []struct {
+		name string
+		add  func() (*graph.Graph, error)
+	}{
+		{
+			name: "add",
+			add: func() (*graph.Graph, error) {
+				g := graph.New()
+				return g, g.Add(events...)
+			},
+		},
+		{
+			name: "from-events",
+			add: func() (*graph.Graph, error) {
+				return graph.FromEvents(events...)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := tt.add()
+			if err == nil {
+				t.Fatal("expected an error for the invalid event")
+			}
+
+			if g == nil {
+				t.Fatal("graph is nil")
+			}
+
+			if g.HasNode(graph.JobID(ns, "job")) {
+				t.Error("invalid event was added")
+			}
+
+			if !g.HasNode(graph.JobID(ns, "other")) {
+				t.Error("valid event was skipped")
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ThijsKoot/openlineage-go"
+)
+
+// WriteMermaid writes the graph to w as a Mermaid flowchart.
+// Jobs are drawn as rectangles coloured by the state of their latest run, datasets as cylinders,
+// and column-level lineage as dotted edges between datasets.
+func (g *Graph) WriteMermaid(w io.Writer, opts ...ExportOption) error {
+	m := g.exportModel(opts)
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "flowchart LR")
+
+	for _, n := range m.nodes {
+		if n.id.Kind == NodeKindDataset {
+			fmt.Fprintf(bw, "    %s[(%s)]:::dataset\n", n.key, mermaidQuote(n.id.Name))
+			continue
+		}
+
+		fmt.Fprintf(bw, "    %s[%s]:::%s\n", n.key, mermaidQuote(n.id.Name), mermaidClass(n.state))
+	}
+
+	for _, e := range m.edges {
+		if !e.column {
+			fmt.Fprintf(bw, "    %s --> %s\n", e.from, e.to)
+			continue
+		}
+
+		fmt.Fprintf(bw, "    %s -.->|%s| %s\n", e.from, mermaidQuote(e.label), e.to)
+	}
+
+	fmt.Fprintf(bw, "    classDef job fill:%s,stroke:#333\n", jobColor)
+	fmt.Fprintf(bw, "    classDef dataset fill:%s,stroke:#333\n", datasetColor)
+	for _, state := range []openlineage.EventType{
+		openlineage.EventTypeStart,
+		openlineage.EventTypeRunning,
+		openlineage.EventTypeComplete,
+		openlineage.EventTypeFail,
+		openlineage.EventTypeAbort,
+	} {
+		fmt.Fprintf(bw, "    classDef %s fill:%s,stroke:#333\n", mermaidClass(state), stateColors[state])
+	}
+
+	return bw.Flush()
+}
+
+// mermaidClass returns the class of a job whose latest run is in state.
+func mermaidClass(state openlineage.EventType) string {
+	if _, ok := stateColors[state]; !ok {
+		return "job"
+	}
+
+	return "job" + strings.ToLower(string(state))
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
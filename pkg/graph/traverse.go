@@ -0,0 +1,145 @@
+package graph
+
+import (
+	"slices"
+)
+
+// Upstream returns the nodes that id depends on, directly or transitively,
+// in order of distance from id. Nodes at the same distance are sorted.
+// If depth is greater than zero, only nodes up to depth edges away are returned.
+func (g *Graph) Upstream(id NodeID, depth int) []NodeID {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return traverse(g.upstream, id, depth)
+}
+
+// Downstream returns the nodes that depend on id, directly or transitively,
+// in order of distance from id. Nodes at the same distance are sorted.
+// If depth is greater than zero, only nodes up to depth edges away are returned.
+func (g *Graph) Downstream(id NodeID, depth int) []NodeID {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return traverse(g.downstream, id, depth)
+}
+
+// traverse performs a breadth-first search from start along edges.
+// start itself is only included if it can be reached through a cycle.
+func traverse(edges map[NodeID]map[NodeID]struct{}, start NodeID, depth int) []NodeID {
+	var result []NodeID
+	visited := map[NodeID]bool{}
+
+	level := []NodeID{start}
+	for d := 1; len(level) > 0 && (depth <= 0 || d <= depth); d++ {
+		var next []NodeID
+		for _, id := range level {
+			for n := range edges[id] {
+				if visited[n] {
+					continue
+				}
+
+				visited[n] = true
+				next = append(next, n)
+			}
+		}
+
+		slices.SortFunc(next, compareNodeIDs)
+		result = append(result, next...)
+		level = next
+	}
+
+	return result
+}
+
+// Cycles returns the cycles in the graph, for example a job that writes to a dataset it reads from,
+// directly or through other jobs.
+// Each cycle is reported as the sorted set of nodes that are part of it.
+// Cycles sharing a node are reported as one.
+func (g *Graph) Cycles() [][]NodeID {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var cycles [][]NodeID
+	for _, component := range g.stronglyConnectedComponents() {
+		if len(component) < 2 && !g.hasEdge(component[0], component[0]) {
+			continue
+		}
+
+		slices.SortFunc(component, compareNodeIDs)
+		cycles = append(cycles, component)
+	}
+
+	slices.SortFunc(cycles, func(a, b []NodeID) int {
+		return compareNodeIDs(a[0], b[0])
+	})
+
+	return cycles
+}
+
+// HasCycles reports whether the graph contains any cycles.
+func (g *Graph) HasCycles() bool {
+	return len(g.Cycles()) > 0
+}
+
+func (g *Graph) hasEdge(from, to NodeID) bool {
+	_, ok := g.downstream[from][to]
+	return ok
+}
+
+// stronglyConnectedComponents implements Tarjan's algorithm.
+func (g *Graph) stronglyConnectedComponents() [][]NodeID {
+	var (
+		index      int
+		indices    = make(map[NodeID]int, len(g.nodes))
+		lowlinks   = make(map[NodeID]int, len(g.nodes))
+		onStack    = make(map[NodeID]bool, len(g.nodes))
+		stack      []NodeID
+		components [][]NodeID
+	)
+
+	var connect func(v NodeID)
+	connect = func(v NodeID) {
+		indices[v] = index
+		lowlinks[v] = index
+		index++
+
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for w := range g.downstream[v] {
+			if _, seen := indices[w]; !seen {
+				connect(w)
+				lowlinks[v] = min(lowlinks[v], lowlinks[w])
+			} else if onStack[w] {
+				lowlinks[v] = min(lowlinks[v], indices[w])
+			}
+		}
+
+		if lowlinks[v] != indices[v] {
+			return
+		}
+
+		var component []NodeID
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			component = append(component, w)
+
+			if w == v {
+				break
+			}
+		}
+
+		components = append(components, component)
+	}
+
+	for v := range g.nodes {
+		if _, seen := indices[v]; !seen {
+			connect(v)
+		}
+	}
+
+	return components
+}
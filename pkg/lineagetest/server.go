@@ -0,0 +1,275 @@
+// Package lineagetest provides a local OpenLineage server for integration tests.
+//
+// The server accepts events on the OpenLineage HTTP endpoint, checks that they contain the fields
+// required by the specification, and stores them in memory for inspection.
+// Faults such as latency and error responses can be injected to test how clients handle an unreliable backend.
+//
+// Events are received by a [receiver.Handler], so the server accepts the same payloads as collectors built on it.
+package lineagetest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/oltest"
+	"github.com/ThijsKoot/openlineage-go/pkg/receiver"
+	"github.com/ThijsKoot/openlineage-go/pkg/transport"
+)
+
+// EventsPath is the path of the query endpoint.
+// GET returns the stored events as a JSON array, filtered by the optional query parameters
+// "namespace", "job", "eventType" and "runId". The namespace is that of the job, or of the dataset for dataset events.
+// DELETE removes all stored events.
+const EventsPath = "/api/v1/events"
+
+// Fault describes a failure injected into the handling of requests to the lineage endpoints.
+type Fault struct {
+	// Latency delays the response.
+	Latency time.Duration
+
+	// StatusCode is returned instead of handling the request, if not zero.
+	StatusCode int
+
+	// RetryAfter is sent as the Retry-After header in whole seconds, if positive.
+	RetryAfter time.Duration
+
+	// Times is the number of requests this fault applies to. Zero applies it to all subsequent requests.
+	Times int
+}
+
+type config struct {
+	endpoint      string
+	batchEndpoint string
+	apiKey        string
+	validate      bool
+}
+
+// Option configures a [Server].
+type Option func(*config)
+
+// WithEndpoint sets the path accepting single events (default: /api/v1/lineage).
+func WithEndpoint(path string) Option {
+	return func(c *config) {
+		c.endpoint = path
+	}
+}
+
+// WithBatchEndpoint enables an additional path accepting events, for clients sending batches.
+// Like the default endpoint, it accepts both single events and JSON arrays of events.
+func WithBatchEndpoint(path string) Option {
+	return func(c *config) {
+		c.batchEndpoint = path
+	}
+}
+
+// WithAPIKey requires requests to the lineage endpoints to carry apiKey as a bearer token.
+func WithAPIKey(apiKey string) Option {
+	return func(c *config) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithoutValidation stores events without checking them with [openlineage.ValidateEvent].
+func WithoutValidation() Option {
+	return func(c *config) {
+		c.validate = false
+	}
+}
+
+// Server is an in-memory OpenLineage server.
+type Server struct {
+	// URL of the server, in the form http://ipaddr:port with no trailing slash.
+	URL string
+
+	cfg      config
+	recorder *oltest.Recorder
+	receiver *receiver.Handler
+	server   *httptest.Server
+
+	mu       sync.Mutex
+	faults   []Fault
+	requests int
+}
+
+// NewServer starts a Server. Call Close when finished to shut it down.
+func NewServer(opts ...Option) *Server {
+	cfg := config{
+		endpoint: "/" + transport.DefaultEndpoint,
+		validate: true,
+	}
+
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	s := &Server{
+		cfg:      cfg,
+		recorder: oltest.NewRecorder(),
+	}
+
+	receiverOpts := []receiver.Option{receiver.WithAPIKey(cfg.apiKey)}
+	if cfg.validate {
+		receiverOpts = append(receiverOpts, receiver.WithStructuralValidation())
+	}
+
+	s.receiver = receiver.NewHandler(receiver.TransportDispatcher(s.recorder), receiverOpts...)
+
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL
+
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Recorder returns the recorder containing all accepted events.
+// It can be used to wait for and filter events, see [oltest.Recorder].
+func (s *Server) Recorder() *oltest.Recorder {
+	return s.recorder
+}
+
+// Events returns all accepted events in the order they were received.
+func (s *Server) Events() []openlineage.Event {
+	return s.recorder.Events()
+}
+
+// Requests returns the number of requests made to the lineage endpoints, including failed ones.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+// Reset removes all stored events, faults and request counts.
+func (s *Server) Reset() {
+	s.recorder.Reset()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+	s.requests = 0
+}
+
+// InjectFault queues a fault. Faults are applied in the order they were injected,
+// to POST requests passing the API key check.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, f)
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case s.cfg.endpoint, s.cfg.batchEndpoint:
+		s.handleLineage(w, r)
+	case EventsPath:
+		s.handleEvents(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) nextFault() (Fault, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.faults) == 0 {
+		return Fault{}, false
+	}
+
+	f := s.faults[0]
+	if f.Times > 0 {
+		s.faults[0].Times--
+		if s.faults[0].Times == 0 {
+			s.faults = s.faults[1:]
+		}
+	}
+
+	return f, true
+}
+
+func (s *Server) handleLineage(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests++
+	s.mu.Unlock()
+
+	// Requests the receiver rejects before reading the body do not consume faults,
+	// so that faults only apply to requests that could deliver events.
+	if r.Method != http.MethodPost || !s.receiver.Authorized(r) {
+		s.receiver.ServeHTTP(w, r)
+		return
+	}
+
+	if fault, ok := s.nextFault(); ok {
+		if fault.Latency > 0 {
+			timer := time.NewTimer(fault.Latency)
+			select {
+			case <-timer.C:
+			case <-r.Context().Done():
+				timer.Stop()
+				return
+			}
+		}
+
+		if fault.StatusCode != 0 {
+			if fault.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter.Seconds())))
+			}
+
+			http.Error(w, http.StatusText(fault.StatusCode), fault.StatusCode)
+			return
+		}
+	}
+
+	s.receiver.ServeHTTP(w, r)
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+
+		var filters []oltest.Filter
+		switch job, namespace := q.Get("job"), q.Get("namespace"); {
+		case job != "":
+			filters = append(filters, oltest.ByJob(namespace, job))
+		case namespace != "":
+			filters = append(filters, oltest.ByNamespace(namespace))
+		}
+
+		if eventType := q.Get("eventType"); eventType != "" {
+			filters = append(filters, oltest.ByEventType(openlineage.EventType(strings.ToUpper(eventType))))
+		}
+
+		if runID := q.Get("runId"); runID != "" {
+			filters = append(filters, oltest.ByRunID(runID))
+		}
+
+		events := oltest.Select(s.recorder.Events(), filters...)
+		if events == nil {
+			events = []openlineage.Event{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(events)
+	case http.MethodDelete:
+		s.recorder.Reset()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
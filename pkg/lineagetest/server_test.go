@@ -0,0 +1,327 @@
+package lineagetest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/lineagetest"
+	"github.com/ThijsKoot/openlineage-go/pkg/transport"
+	"github.com/google/uuid"
+)
+
+func newHTTPClient(t *testing.T, srv *lineagetest.Server, apiKey string) *openlineage.Client {
+	t.Helper()
+
+	client, err := openlineage.NewClient(openlineage.ClientConfig{
+		Namespace: "test",
+		Transport: transport.Config{
+			Type: transport.TransportTypeHTTP,
+			HTTP: &transport.HTTPConfig{
+				URL:    srv.URL,
+				APIKey: apiKey,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create client: %s", err)
+	}
+
+	return client
+}
+
+func post(t *testing.T, url string, body any) *http.Response {
+	t.Helper()
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	return resp
+}
+
+func Test_Server_Emit(t *testing.T) {
+	srv := lineagetest.NewServer(lineagetest.WithAPIKey("secret"))
+	defer srv.Close()
+
+	client := newHTTPClient(t, srv, "secret")
+
+	runID := uuid.Must(uuid.NewV7())
+	for _, eventType := range []openlineage.EventType{openlineage.EventTypeStart, openlineage.EventTypeComplete} {
+		if err := client.Emit(context.Background(), client.NewRunEvent(eventType, runID, "job")); err != nil {
+			t.Fatalf("emit %s: %s", eventType, err)
+		}
+	}
+
+	if n := len(srv.Events()); n != 2 {
+		t.Fatalf("server stored %d events, want 2", n)
+	}
+
+	resp, err := http.Get(srv.URL + lineagetest.EventsPath + "?job=job&eventType=complete")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var events []openlineage.Event
+	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
+		t.Fatalf("decode events: %s", err)
+	}
+
+	if len(events) != 1 || *events[0].EventType != openlineage.EventTypeComplete {
+		t.Errorf("query returned %+v, want the COMPLETE event", events)
+	}
+}
+
+func Test_Server_Unauthorized(t *testing.T) {
+	srv := lineagetest.NewServer(lineagetest.WithAPIKey("secret"))
+	defer srv.Close()
+
+	client := newHTTPClient(t, srv, "wrong")
+	event := client.NewRunEvent(openlineage.EventTypeStart, uuid.Must(uuid.NewV7()), "job")
+
+	if err := client.Emit(context.Background(), event); err == nil {
+		t.Error("emit with wrong API key succeeded")
+	}
+
+	if n := len(srv.Events()); n != 0 {
+		t.Errorf("server stored %d events, want 0", n)
+	}
+}
+
+func Test_Server_Validation(t *testing.T) {
+	srv := lineagetest.NewServer()
+	defer srv.Close()
+
+	resp := post(t, srv.URL+"/api/v1/lineage", map[string]any{
+		"eventTime": "now",
+		"run":       map[string]any{"runId": "123"},
+	})
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	if n := len(srv.Events()); n != 0 {
+		t.Errorf("server stored %d events, want 0", n)
+	}
+}
+
+func Test_Server_Batch(t *testing.T) {
+	srv := lineagetest.NewServer(lineagetest.WithBatchEndpoint("/api/v1/lineage/batch"))
+	defer srv.Close()
+
+	runID := uuid.Must(uuid.NewV7())
+	batch := []openlineage.Event{
+		openlineage.NewRunEvent(openlineage.EventTypeStart, runID, "job").AsEmittable(),
+		openlineage.NewRunEvent(openlineage.EventTypeComplete, runID, "job").AsEmittable(),
+	}
+
+	resp := post(t, srv.URL+"/api/v1/lineage/batch", batch)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+
+	if n := len(srv.Events()); n != 2 {
+		t.Errorf("server stored %d events, want 2", n)
+	}
+}
+
+func Test_Server_Faults(t *testing.T) {
+	cases := []struct {
+		name     string
+		fault    lineagetest.Fault
+		wantReqs int
+	}{
+		{
+			name:     "server-error",
+			fault:    lineagetest.Fault{StatusCode: http.StatusServiceUnavailable, Times: 1},
+			wantReqs: 2,
+		},
+		{
+			name: "rate-limited",
+			fault: lineagetest.Fault{
+				StatusCode: http.StatusTooManyRequests,
+				RetryAfter: time.Second,
+				Times:      1,
+			},
+			wantReqs: 2,
+		},
+		{
+			name:     "latency",
+			fault:    lineagetest.Fault{Latency: 50 * time.Millisecond, Times: 1},
+			wantReqs: 1,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := lineagetest.NewServer()
+			defer srv.Close()
+
+			srv.InjectFault(tt.fault)
+
+			client := newHTTPClient(t, srv, "")
+			event := client.NewRunEvent(openlineage.EventTypeStart, uuid.Must(uuid.NewV7()), "job")
+
+			start := time.Now()
+			if err := client.Emit(context.Background(), event); err != nil {
+				t.Fatalf("emit failed despite retries: %s", err)
+			}
+
+			if elapsed := time.Since(start); elapsed < tt.fault.Latency+tt.fault.RetryAfter {
+				t.Errorf("emit took %s, want at least %s", elapsed, tt.fault.Latency+tt.fault.RetryAfter)
+			}
+
+			if n := srv.Requests(); n != tt.wantReqs {
+				t.Errorf("server received %d requests, want %d", n, tt.wantReqs)
+			}
+
+			if n := len(srv.Events()); n != 1 {
+				t.Errorf("server stored %d events, want 1", n)
+			}
+		})
+	}
+}
+
+func Test_Server_FaultsSkipRejectedRequests(t *testing.T) {
+	srv := lineagetest.NewServer(lineagetest.WithAPIKey("secret"))
+	defer srv.Close()
+
+	srv.InjectFault(lineagetest.Fault{StatusCode: http.StatusServiceUnavailable, Times: 1})
+
+	resp, err := http.Get(srv.URL + "/api/v1/lineage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+
+	event := openlineage.NewRunEvent(openlineage.EventTypeStart, uuid.Must(uuid.NewV7()), "job").AsEmittable()
+	if resp := post(t, srv.URL+"/api/v1/lineage", event); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unauthorized POST status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/api/v1/lineage", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer secret")
+
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("authorized POST status = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+
+	if n := srv.Requests(); n != 3 {
+		t.Errorf("server received %d requests, want 3", n)
+	}
+}
+
+func Test_Server_LatencyCanceled(t *testing.T) {
+	srv := lineagetest.NewServer()
+	defer srv.Close()
+
+	srv.InjectFault(lineagetest.Fault{Latency: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// The server only notices a client going away once the request body was read,
+	// which the handler does after the latency, so send none.
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/api/v1/lineage", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := http.DefaultClient.Do(req); err == nil {
+		t.Fatal("request succeeded despite latency exceeding its deadline")
+	}
+
+	// Close waits for outstanding requests, so it blocks for the full latency if the handler ignores cancelation.
+	done := make(chan struct{})
+	go func() {
+		srv.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop waiting after the request was canceled")
+	}
+}
+
+func Test_Server_EventsQuery(t *testing.T) {
+	srv := lineagetest.NewServer()
+	defer srv.Close()
+
+	runID := uuid.Must(uuid.NewV7())
+	dataset := openlineage.NewDatasetEvent("users", "warehouse")
+	events := []openlineage.Event{
+		openlineage.NewNamespacedRunEvent(openlineage.EventTypeStart, runID, "load", "warehouse").AsEmittable(),
+		openlineage.NewNamespacedRunEvent(openlineage.EventTypeComplete, runID, "load", "warehouse").AsEmittable(),
+		openlineage.NewNamespacedRunEvent(openlineage.EventTypeStart, uuid.Must(uuid.NewV7()), "load", "lake").AsEmittable(),
+		dataset.AsEmittable(),
+	}
+
+	if resp := post(t, srv.URL+"/api/v1/lineage", events); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{query: "", want: 4},
+		{query: "?namespace=warehouse", want: 3},
+		{query: "?namespace=lake", want: 1},
+		{query: "?namespace=warehouse&job=load", want: 2},
+		{query: "?job=load", want: 3},
+		{query: "?namespace=warehouse&eventType=start", want: 1},
+		{query: "?namespace=unknown", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + lineagetest.EventsPath + tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			var got []openlineage.Event
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("decode events: %s", err)
+			}
+
+			if len(got) != tt.want {
+				t.Errorf("query returned %d events, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
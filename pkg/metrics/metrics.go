@@ -0,0 +1,67 @@
+// Package metrics defines the measurements taken while emitting events.
+//
+// An [openlineage.Client] created with [openlineage.WithMetrics] reports to a [Recorder],
+// as do the built-in transports it creates.
+// Adapters for Prometheus and OpenTelemetry are available in the prommetrics and otelmetrics packages.
+package metrics
+
+import (
+	"context"
+	"time"
+)
+
+// Reasons for events to be dropped, see [Recorder.Dropped].
+const (
+	// DroppedDisabled is the reason for events emitted by a disabled client.
+	DroppedDisabled = "disabled"
+
+	// DroppedRule is the reason for events matching a rule that disables them.
+	DroppedRule = "rule"
+)
+
+// Reasons for failures detected by the client before calling a transport, see [Recorder.Failed].
+const (
+	// FailedNoTransport is the reason for events that a rule routes to a transport the client was not created with.
+	FailedNoTransport = "no_transport"
+)
+
+// Recorder receives measurements of event emission.
+// Implementations must be safe for concurrent use.
+type Recorder interface {
+	// Emitted is called when an event was emitted by transport, with the time it took.
+	// eventType is the type of run events, or JOB or DATASET for job and dataset events.
+	Emitted(ctx context.Context, transport, eventType string, d time.Duration)
+
+	// Failed is called when emitting an event failed, with a short description of the cause such as "timeout" or "status_503".
+	// For [FailedNoTransport], no transport was called and d is zero, so it should not be recorded as a duration.
+	Failed(ctx context.Context, transport, eventType, reason string, d time.Duration)
+
+	// Retried is called when transport retries a request.
+	Retried(ctx context.Context, transport string)
+
+	// QueueDepth is called with +1 when an event is queued to be emitted asynchronously, and with -1 once it has been handled.
+	QueueDepth(ctx context.Context, delta int64)
+
+	// Dropped is called when an event is discarded without being emitted.
+	Dropped(ctx context.Context, reason string)
+}
+
+var _ Recorder = Noop{}
+
+// Noop is a Recorder that discards all measurements.
+type Noop struct{}
+
+// Emitted implements Recorder.
+func (Noop) Emitted(context.Context, string, string, time.Duration) {}
+
+// Failed implements Recorder.
+func (Noop) Failed(context.Context, string, string, string, time.Duration) {}
+
+// Retried implements Recorder.
+func (Noop) Retried(context.Context, string) {}
+
+// QueueDepth implements Recorder.
+func (Noop) QueueDepth(context.Context, int64) {}
+
+// Dropped implements Recorder.
+func (Noop) Dropped(context.Context, string) {}
@@ -0,0 +1,22 @@
+module github.com/ThijsKoot/openlineage-go/pkg/metrics/otelmetrics
+
+go 1.22.4
+
+require (
+	github.com/ThijsKoot/openlineage-go v0.0.0-00010101000000-000000000000
+	github.com/go-test/deep v1.1.1
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/metric v1.28.0
+	go.opentelemetry.io/otel/sdk/metric v1.28.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/otel/sdk v1.28.0 // indirect
+	go.opentelemetry.io/otel/trace v1.28.0 // indirect
+	golang.org/x/sys v0.22.0 // indirect
+)
+
+replace github.com/ThijsKoot/openlineage-go => ../../..
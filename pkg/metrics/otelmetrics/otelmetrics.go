@@ -0,0 +1,114 @@
+// Package otelmetrics reports the measurements of package metrics as OpenTelemetry metrics.
+package otelmetrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// ScopeName is the instrumentation scope of the meter used by [New].
+const ScopeName = "github.com/ThijsKoot/openlineage-go"
+
+// Attribute keys of the measurements.
+const (
+	TransportKey = attribute.Key("openlineage.transport")
+	EventTypeKey = attribute.Key("openlineage.event_type")
+	ReasonKey    = attribute.Key("openlineage.reason")
+)
+
+var _ metrics.Recorder = (*Recorder)(nil)
+
+// Recorder is a [metrics.Recorder] that records OpenTelemetry instruments:
+//
+//   - openlineage.events.emitted, by transport and event type
+//   - openlineage.emit.failures, by transport, event type and reason
+//   - openlineage.emit.duration, a histogram in seconds by transport, including failed attempts
+//   - openlineage.transport.retries, by transport
+//   - openlineage.queue.depth, the number of events waiting to be emitted asynchronously
+//   - openlineage.events.dropped, by reason
+type Recorder struct {
+	emitted    metric.Int64Counter
+	failures   metric.Int64Counter
+	duration   metric.Float64Histogram
+	retries    metric.Int64Counter
+	queueDepth metric.Int64UpDownCounter
+	dropped    metric.Int64Counter
+}
+
+// New creates a Recorder using a meter of mp.
+func New(mp metric.MeterProvider) (*Recorder, error) {
+	meter := mp.Meter(ScopeName)
+
+	var (
+		r    Recorder
+		err  error
+		errs []error
+	)
+
+	r.emitted, err = meter.Int64Counter("openlineage.events.emitted",
+		metric.WithDescription("Number of events emitted."), metric.WithUnit("{event}"))
+	errs = append(errs, err)
+
+	r.failures, err = meter.Int64Counter("openlineage.emit.failures",
+		metric.WithDescription("Number of events that could not be emitted."), metric.WithUnit("{event}"))
+	errs = append(errs, err)
+
+	r.duration, err = meter.Float64Histogram("openlineage.emit.duration",
+		metric.WithDescription("Time taken to emit an event, including retries."), metric.WithUnit("s"))
+	errs = append(errs, err)
+
+	r.retries, err = meter.Int64Counter("openlineage.transport.retries",
+		metric.WithDescription("Number of requests retried by transports."), metric.WithUnit("{request}"))
+	errs = append(errs, err)
+
+	r.queueDepth, err = meter.Int64UpDownCounter("openlineage.queue.depth",
+		metric.WithDescription("Number of events waiting to be emitted asynchronously."), metric.WithUnit("{event}"))
+	errs = append(errs, err)
+
+	r.dropped, err = meter.Int64Counter("openlineage.events.dropped",
+		metric.WithDescription("Number of events discarded without being emitted."), metric.WithUnit("{event}"))
+	errs = append(errs, err)
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// Emitted implements metrics.Recorder.
+func (r *Recorder) Emitted(ctx context.Context, transport, eventType string, d time.Duration) {
+	r.emitted.Add(ctx, 1, metric.WithAttributes(TransportKey.String(transport), EventTypeKey.String(eventType)))
+	r.duration.Record(ctx, d.Seconds(), metric.WithAttributes(TransportKey.String(transport)))
+}
+
+// Failed implements metrics.Recorder.
+func (r *Recorder) Failed(ctx context.Context, transport, eventType, reason string, d time.Duration) {
+	r.failures.Add(ctx, 1, metric.WithAttributes(
+		TransportKey.String(transport), EventTypeKey.String(eventType), ReasonKey.String(reason),
+	))
+
+	if reason != metrics.FailedNoTransport {
+		r.duration.Record(ctx, d.Seconds(), metric.WithAttributes(TransportKey.String(transport)))
+	}
+}
+
+// Retried implements metrics.Recorder.
+func (r *Recorder) Retried(ctx context.Context, transport string) {
+	r.retries.Add(ctx, 1, metric.WithAttributes(TransportKey.String(transport)))
+}
+
+// QueueDepth implements metrics.Recorder.
+func (r *Recorder) QueueDepth(ctx context.Context, delta int64) {
+	r.queueDepth.Add(ctx, delta)
+}
+
+// Dropped implements metrics.Recorder.
+func (r *Recorder) Dropped(ctx context.Context, reason string) {
+	r.dropped.Add(ctx, 1, metric.WithAttributes(ReasonKey.String(reason)))
+}
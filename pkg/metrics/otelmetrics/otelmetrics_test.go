@@ -0,0 +1,87 @@
+package otelmetrics_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go/pkg/metrics"
+	"github.com/ThijsKoot/openlineage-go/pkg/metrics/otelmetrics"
+	"github.com/go-test/deep"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// sums returns the values of the sum instruments in rm, keyed by instrument name and attributes.
+func sums(rm metricdata.ResourceMetrics) map[string]int64 {
+	got := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				continue
+			}
+
+			for _, dp := range sum.DataPoints {
+				got[m.Name+" "+dp.Attributes.Encoded(attribute.DefaultEncoder())] = dp.Value
+			}
+		}
+	}
+
+	return got
+}
+
+func Test_Recorder(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	r, err := otelmetrics.New(mp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r.Emitted(ctx, "http", "START", 50*time.Millisecond)
+	r.Emitted(ctx, "http", "START", 50*time.Millisecond)
+	r.Failed(ctx, "http", "COMPLETE", "status_503", 2*time.Second)
+	r.Failed(ctx, "http", "COMPLETE", metrics.FailedNoTransport, 0)
+	r.Retried(ctx, "http")
+	r.QueueDepth(ctx, 1)
+	r.QueueDepth(ctx, 1)
+	r.QueueDepth(ctx, -1)
+	r.Dropped(ctx, "disabled")
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]int64{
+		"openlineage.events.emitted openlineage.event_type=START,openlineage.transport=http":                                   2,
+		"openlineage.emit.failures openlineage.event_type=COMPLETE,openlineage.reason=status_503,openlineage.transport=http":   1,
+		"openlineage.emit.failures openlineage.event_type=COMPLETE,openlineage.reason=no_transport,openlineage.transport=http": 1,
+		"openlineage.transport.retries openlineage.transport=http":                                                             1,
+		"openlineage.queue.depth ":                               1,
+		"openlineage.events.dropped openlineage.reason=disabled": 1,
+	}
+
+	if diff := deep.Equal(want, sums(rm)); diff != nil {
+		t.Errorf("differences found:\n%s", diff)
+	}
+
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != "openlineage.emit.duration" {
+			continue
+		}
+
+		dp := m.Data.(metricdata.Histogram[float64]).DataPoints[0]
+		if dp.Count != 3 || dp.Sum < 2.09 || dp.Sum > 2.11 {
+			t.Errorf("unexpected duration histogram: count %d, sum %f", dp.Count, dp.Sum)
+		}
+
+		return
+	}
+
+	t.Error("no openlineage.emit.duration histogram was recorded")
+}
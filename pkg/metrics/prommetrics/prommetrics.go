@@ -0,0 +1,136 @@
+// Package prommetrics reports the measurements of package metrics as Prometheus metrics.
+package prommetrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ metrics.Recorder = (*Recorder)(nil)
+
+// Option configures a [Recorder].
+type Option func(*config)
+
+type config struct {
+	namespace string
+	buckets   []float64
+}
+
+// WithNamespace sets the prefix of metric names. Defaults to "openlineage".
+func WithNamespace(namespace string) Option {
+	return func(c *config) {
+		c.namespace = namespace
+	}
+}
+
+// WithBuckets sets the buckets of the emit duration histogram, in seconds. Defaults to [prometheus.DefBuckets].
+func WithBuckets(buckets ...float64) Option {
+	return func(c *config) {
+		c.buckets = buckets
+	}
+}
+
+// Recorder is a [metrics.Recorder] that updates Prometheus metrics:
+//
+//   - openlineage_events_emitted_total, by transport and event_type
+//   - openlineage_emit_failures_total, by transport, event_type and reason
+//   - openlineage_emit_duration_seconds, a histogram by transport, including failed attempts
+//   - openlineage_transport_retries_total, by transport
+//   - openlineage_queue_depth, the number of events waiting to be emitted asynchronously
+//   - openlineage_events_dropped_total, by reason
+type Recorder struct {
+	emitted    *prometheus.CounterVec
+	failures   *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	retries    *prometheus.CounterVec
+	queueDepth prometheus.Gauge
+	dropped    *prometheus.CounterVec
+}
+
+// New creates a Recorder and registers its metrics with reg.
+func New(reg prometheus.Registerer, opts ...Option) (*Recorder, error) {
+	cfg := config{
+		namespace: "openlineage",
+		buckets:   prometheus.DefBuckets,
+	}
+
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	r := &Recorder{
+		emitted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "events_emitted_total",
+			Help:      "Number of events emitted.",
+		}, []string{"transport", "event_type"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "emit_failures_total",
+			Help:      "Number of events that could not be emitted.",
+		}, []string{"transport", "event_type", "reason"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.namespace,
+			Name:      "emit_duration_seconds",
+			Help:      "Time taken to emit an event, including retries.",
+			Buckets:   cfg.buckets,
+		}, []string{"transport"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "transport_retries_total",
+			Help:      "Number of requests retried by transports.",
+		}, []string{"transport"}),
+		queueDepth: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: cfg.namespace,
+			Name:      "queue_depth",
+			Help:      "Number of events waiting to be emitted asynchronously.",
+		}),
+		dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "events_dropped_total",
+			Help:      "Number of events discarded without being emitted.",
+		}, []string{"reason"}),
+	}
+
+	for _, c := range []prometheus.Collector{r.emitted, r.failures, r.duration, r.retries, r.queueDepth, r.dropped} {
+		if err := reg.Register(c); err != nil {
+			return nil, fmt.Errorf("register metric: %w", err)
+		}
+	}
+
+	return r, nil
+}
+
+// Emitted implements metrics.Recorder.
+func (r *Recorder) Emitted(_ context.Context, transport, eventType string, d time.Duration) {
+	r.emitted.WithLabelValues(transport, eventType).Inc()
+	r.duration.WithLabelValues(transport).Observe(d.Seconds())
+}
+
+// Failed implements metrics.Recorder.
+func (r *Recorder) Failed(_ context.Context, transport, eventType, reason string, d time.Duration) {
+	r.failures.WithLabelValues(transport, eventType, reason).Inc()
+
+	if reason != metrics.FailedNoTransport {
+		r.duration.WithLabelValues(transport).Observe(d.Seconds())
+	}
+}
+
+// Retried implements metrics.Recorder.
+func (r *Recorder) Retried(_ context.Context, transport string) {
+	r.retries.WithLabelValues(transport).Inc()
+}
+
+// QueueDepth implements metrics.Recorder.
+func (r *Recorder) QueueDepth(_ context.Context, delta int64) {
+	r.queueDepth.Add(float64(delta))
+}
+
+// Dropped implements metrics.Recorder.
+func (r *Recorder) Dropped(_ context.Context, reason string) {
+	r.dropped.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,82 @@
+package prommetrics_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go/pkg/metrics"
+	"github.com/ThijsKoot/openlineage-go/pkg/metrics/prommetrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func Test_Recorder(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	r, err := prommetrics.New(reg, prommetrics.WithBuckets(0.1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r.Emitted(ctx, "http", "START", 50*time.Millisecond)
+	r.Emitted(ctx, "http", "START", 50*time.Millisecond)
+	r.Failed(ctx, "http", "COMPLETE", "status_503", 2*time.Second)
+	r.Failed(ctx, "http", "COMPLETE", metrics.FailedNoTransport, 0)
+	r.Retried(ctx, "http")
+	r.QueueDepth(ctx, 1)
+	r.QueueDepth(ctx, 1)
+	r.QueueDepth(ctx, -1)
+	r.Dropped(ctx, "disabled")
+
+	want := `
+# HELP openlineage_emit_duration_seconds Time taken to emit an event, including retries.
+# TYPE openlineage_emit_duration_seconds histogram
+openlineage_emit_duration_seconds_bucket{transport="http",le="0.1"} 2
+openlineage_emit_duration_seconds_bucket{transport="http",le="1"} 2
+openlineage_emit_duration_seconds_bucket{transport="http",le="+Inf"} 3
+openlineage_emit_duration_seconds_sum{transport="http"} 2.1
+openlineage_emit_duration_seconds_count{transport="http"} 3
+# HELP openlineage_emit_failures_total Number of events that could not be emitted.
+# TYPE openlineage_emit_failures_total counter
+openlineage_emit_failures_total{event_type="COMPLETE",reason="no_transport",transport="http"} 1
+openlineage_emit_failures_total{event_type="COMPLETE",reason="status_503",transport="http"} 1
+# HELP openlineage_events_dropped_total Number of events discarded without being emitted.
+# TYPE openlineage_events_dropped_total counter
+openlineage_events_dropped_total{reason="disabled"} 1
+# HELP openlineage_events_emitted_total Number of events emitted.
+# TYPE openlineage_events_emitted_total counter
+openlineage_events_emitted_total{event_type="START",transport="http"} 2
+# HELP openlineage_queue_depth Number of events waiting to be emitted asynchronously.
+# TYPE openlineage_queue_depth gauge
+openlineage_queue_depth 1
+# HELP openlineage_transport_retries_total Number of requests retried by transports.
+# TYPE openlineage_transport_retries_total counter
+openlineage_transport_retries_total{transport="http"} 1
+`
+
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
+
+func Test_New_Namespace(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	r, err := prommetrics.New(reg, prommetrics.WithNamespace("lineage"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r.Dropped(context.Background(), "disabled")
+
+	if n, err := testutil.GatherAndCount(reg, "lineage_events_dropped_total"); err != nil || n != 1 {
+		t.Errorf("expected 1 series of lineage_events_dropped_total, got %d: %v", n, err)
+	}
+
+	if _, err := prommetrics.New(reg, prommetrics.WithNamespace("lineage")); err == nil {
+		t.Error("expected an error when registering twice")
+	}
+}
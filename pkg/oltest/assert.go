@@ -0,0 +1,163 @@
+package oltest
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go"
+)
+
+// RunTree describes the expected structure of a tree of runs, identified by job name.
+type RunTree struct {
+	Job      string
+	Children []RunTree
+}
+
+// AssertRunTree asserts that events contain a run of want.Job without a parent,
+// whose child runs match want.Children recursively.
+// Children are matched by job name regardless of their order, and unexpected children are reported.
+// Every run in the tree is also checked with [AssertLifecycle].
+func AssertRunTree(t testing.TB, events []openlineage.Event, want RunTree) {
+	t.Helper()
+
+	runs := collectRuns(events)
+
+	var roots []*runNode
+	for _, id := range runs.order {
+		n := runs.nodes[id]
+		if n.job == want.Job && (n.parentID == "" || runs.nodes[n.parentID] == nil) {
+			roots = append(roots, n)
+		}
+	}
+
+	if len(roots) == 0 {
+		t.Errorf("no root run found for job %q", want.Job)
+		return
+	}
+
+	var problems []string
+	for _, root := range roots {
+		problems = compareTree(root, want, want.Job)
+		if len(problems) == 0 {
+			break
+		}
+	}
+
+	for _, p := range problems {
+		t.Error(p)
+	}
+
+	AssertLifecycle(t, events)
+}
+
+func compareTree(got *runNode, want RunTree, path string) []string {
+	var problems []string
+
+	remaining := slices.Clone(got.children)
+	for _, wantChild := range want.Children {
+		childPath := path + "/" + wantChild.Job
+
+		i := slices.IndexFunc(remaining, func(n *runNode) bool {
+			return n.job == wantChild.Job
+		})
+		if i < 0 {
+			problems = append(problems, fmt.Sprintf("%s: run not found", childPath))
+			continue
+		}
+
+		problems = append(problems, compareTree(remaining[i], wantChild, childPath)...)
+		remaining = slices.Delete(remaining, i, i+1)
+	}
+
+	for _, extra := range remaining {
+		problems = append(problems, fmt.Sprintf("%s/%s: unexpected run %s", path, extra.job, extra.runID))
+	}
+
+	return problems
+}
+
+// AssertLifecycle asserts that every run in events has at most one START event and at most one
+// terminal (COMPLETE, FAIL or ABORT) event, and that its START event precedes its terminal event.
+func AssertLifecycle(t testing.TB, events []openlineage.Event) {
+	t.Helper()
+
+	runs := collectRuns(events)
+	for _, id := range runs.order {
+		n := runs.nodes[id]
+
+		var starts, terminals []int
+		for i, eventType := range n.eventTypes {
+			switch eventType {
+			case openlineage.EventTypeStart:
+				starts = append(starts, i)
+			case openlineage.EventTypeComplete, openlineage.EventTypeFail, openlineage.EventTypeAbort:
+				terminals = append(terminals, i)
+			}
+		}
+
+		name := fmt.Sprintf("run %s of job %q", id, n.job)
+		if len(starts) > 1 {
+			t.Errorf("%s has %d START events", name, len(starts))
+		}
+
+		if len(terminals) > 1 {
+			t.Errorf("%s has %d terminal events", name, len(terminals))
+		}
+
+		if len(starts) > 0 && len(terminals) > 0 && terminals[0] < starts[0] {
+			t.Errorf("%s: %s event was emitted before START", name, n.eventTypes[terminals[0]])
+		}
+	}
+}
+
+type runNode struct {
+	runID      string
+	job        string
+	parentID   string
+	eventTypes []openlineage.EventType
+	children   []*runNode
+}
+
+type runIndex struct {
+	nodes map[string]*runNode
+	order []string
+}
+
+func collectRuns(events []openlineage.Event) runIndex {
+	idx := runIndex{nodes: map[string]*runNode{}}
+
+	for _, e := range events {
+		if e.Run == nil {
+			continue
+		}
+
+		n, ok := idx.nodes[e.Run.RunID]
+		if !ok {
+			n = &runNode{runID: e.Run.RunID}
+			idx.nodes[e.Run.RunID] = n
+			idx.order = append(idx.order, e.Run.RunID)
+		}
+
+		if e.Job != nil {
+			n.job = e.Job.Name
+		}
+
+		if e.EventType != nil {
+			n.eventTypes = append(n.eventTypes, *e.EventType)
+		}
+
+		if f := e.Run.Facets; f != nil && f.Parent != nil {
+			n.parentID = f.Parent.Run.RunID
+		}
+	}
+
+	for _, id := range idx.order {
+		n := idx.nodes[id]
+		if parent, ok := idx.nodes[n.parentID]; ok {
+			parent.children = append(parent.children, n)
+		}
+	}
+
+	return idx
+}
@@ -0,0 +1,79 @@
+package oltest
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/google/uuid"
+)
+
+var _ openlineage.Clock = (*Clock)(nil)
+
+// Clock is an [openlineage.Clock] that returns a fixed time, advancing it by a fixed step on every call.
+// It is safe for concurrent use.
+type Clock struct {
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+// NewClock creates a Clock starting at start, advancing by step on every call to Now.
+// Use a step of zero for a clock that always returns start.
+func NewClock(start time.Time, step time.Duration) *Clock {
+	return &Clock{
+		now:  start,
+		step: step,
+	}
+}
+
+// Now implements openlineage.Clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now
+	c.now = c.now.Add(c.step)
+
+	return now
+}
+
+var _ openlineage.IDGenerator = (*IDSequence)(nil)
+
+// IDSequence is an [openlineage.IDGenerator] that returns a predictable sequence of UUIDs.
+// It is safe for concurrent use.
+type IDSequence struct {
+	mu   sync.Mutex
+	ids  []uuid.UUID
+	next uint64
+}
+
+// NewIDSequence creates an IDSequence that returns ids in order.
+// Once they are exhausted, or if none are supplied, it returns UUIDs whose last 8 bytes contain a counter,
+// starting with 00000000-0000-0000-0000-000000000001.
+func NewIDSequence(ids ...uuid.UUID) *IDSequence {
+	return &IDSequence{
+		ids: ids,
+	}
+}
+
+// NewID implements openlineage.IDGenerator.
+func (s *IDSequence) NewID() uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.ids) > 0 {
+		id := s.ids[0]
+		s.ids = s.ids[1:]
+
+		return id
+	}
+
+	s.next++
+
+	var id uuid.UUID
+	binary.BigEndian.PutUint64(id[8:], s.next)
+
+	return id
+}
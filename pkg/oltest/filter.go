@@ -0,0 +1,66 @@
+package oltest
+
+import (
+	"github.com/ThijsKoot/openlineage-go"
+)
+
+// Filter selects events.
+type Filter func(openlineage.Event) bool
+
+// Select returns the events matching all filters.
+func Select(events []openlineage.Event, filters ...Filter) []openlineage.Event {
+	var selected []openlineage.Event
+
+outer:
+	for _, e := range events {
+		for _, f := range filters {
+			if !f(e) {
+				continue outer
+			}
+		}
+
+		selected = append(selected, e)
+	}
+
+	return selected
+}
+
+// ByJob selects events for the job with the supplied name.
+// If namespace is not empty, the job's namespace has to match as well.
+func ByJob(namespace, name string) Filter {
+	return func(e openlineage.Event) bool {
+		if e.Job == nil || e.Job.Name != name {
+			return false
+		}
+
+		return namespace == "" || e.Job.Namespace == namespace
+	}
+}
+
+// ByNamespace selects events for jobs in the supplied namespace, and dataset events for datasets in it.
+func ByNamespace(namespace string) Filter {
+	return func(e openlineage.Event) bool {
+		switch {
+		case e.Job != nil:
+			return e.Job.Namespace == namespace
+		case e.Dataset != nil:
+			return e.Dataset.Namespace == namespace
+		}
+
+		return false
+	}
+}
+
+// ByEventType selects run events of the supplied type.
+func ByEventType(eventType openlineage.EventType) Filter {
+	return func(e openlineage.Event) bool {
+		return e.EventType != nil && *e.EventType == eventType
+	}
+}
+
+// ByRunID selects run events for the run with the supplied ID.
+func ByRunID(runID string) Filter {
+	return func(e openlineage.Event) bool {
+		return e.Run != nil && e.Run.RunID == runID
+	}
+}
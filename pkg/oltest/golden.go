@@ -0,0 +1,143 @@
+package oltest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/tidwall/pretty"
+)
+
+// UpdateGoldenEnv is the environment variable that makes [AssertGolden] write golden files instead of comparing them.
+const UpdateGoldenEnv = "OLTEST_UPDATE_GOLDEN"
+
+var uuidPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+// AssertGolden compares events with the golden file at path, ignoring timestamps and UUIDs (see [Normalize]).
+// If the environment variable OLTEST_UPDATE_GOLDEN is set, the golden file is written instead.
+func AssertGolden(t testing.TB, path string, events []openlineage.Event) {
+	t.Helper()
+
+	got, err := Normalize(events)
+	if err != nil {
+		t.Fatalf("normalize events: %s", err)
+	}
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create golden file directory: %s", err)
+		}
+
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write golden file: %s", err)
+		}
+
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (set %s to create it): %s", UpdateGoldenEnv, err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("events do not match golden file %s (set %s to update it)\ngot:\n%s\nwant:\n%s", path, UpdateGoldenEnv, got, want)
+	}
+}
+
+// Normalize renders events as a pretty-printed JSON array that is stable across executions.
+//
+// Event times are replaced with "<time>" and UUIDs with "<uuid-N>",
+// where N is assigned in order of appearance, so references between runs are preserved.
+// Events are sorted by job, event type and content, since asynchronously emitted events may be recorded in any order.
+func Normalize(events []openlineage.Event) ([]byte, error) {
+	type entry struct {
+		key  string
+		body []byte
+	}
+
+	entries := make([]entry, 0, len(events))
+	for _, e := range events {
+		e.EventTime = "<time>"
+
+		body, err := marshal(e)
+		if err != nil {
+			return nil, fmt.Errorf("marshal event: %w", err)
+		}
+
+		var job, eventType string
+		if e.Job != nil {
+			job = e.Job.Namespace + "/" + e.Job.Name
+		}
+
+		if e.EventType != nil {
+			eventType = eventTypeOrder(*e.EventType)
+		}
+
+		withoutIDs := uuidPattern.ReplaceAll(body, []byte("<uuid>"))
+		entries = append(entries, entry{
+			key:  job + "\x00" + eventType + "\x00" + string(withoutIDs),
+			body: body,
+		})
+	}
+
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		return bytes.Compare([]byte(a.key), []byte(b.key))
+	})
+
+	bodies := make([]json.RawMessage, len(entries))
+	for i, e := range entries {
+		bodies[i] = e.body
+	}
+
+	out, err := marshal(bodies)
+	if err != nil {
+		return nil, fmt.Errorf("marshal events: %w", err)
+	}
+
+	ids := map[string]string{}
+	out = uuidPattern.ReplaceAllFunc(out, func(id []byte) []byte {
+		placeholder, ok := ids[string(id)]
+		if !ok {
+			placeholder = fmt.Sprintf("<uuid-%d>", len(ids)+1)
+			ids[string(id)] = placeholder
+		}
+
+		return []byte(placeholder)
+	})
+
+	return pretty.Pretty(out), nil
+}
+
+// marshal encodes v as JSON without escaping HTML characters, to keep placeholders readable.
+func marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSpace(buf.Bytes()), nil
+}
+
+// eventTypeOrder returns a sort key that orders event types by their place in a run's lifecycle.
+func eventTypeOrder(eventType openlineage.EventType) string {
+	order := map[openlineage.EventType]string{
+		openlineage.EventTypeStart:    "0",
+		openlineage.EventTypeRunning:  "1",
+		openlineage.EventTypeOther:    "2",
+		openlineage.EventTypeComplete: "3",
+		openlineage.EventTypeFail:     "3",
+		openlineage.EventTypeAbort:    "3",
+	}
+
+	return order[eventType] + string(eventType)
+}
@@ -0,0 +1,177 @@
+package oltest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/oltest"
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+)
+
+// fakeTB records failures instead of failing the test.
+type fakeTB struct {
+	testing.TB
+	errors []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Error(args ...any) {
+	f.errors = append(f.errors, fmt.Sprint(args...))
+}
+
+// emitTree emits a root run with two children, the second of which has a child of its own.
+func emitTree(t *testing.T) []openlineage.Event {
+	t.Helper()
+
+	client, rec := oltest.NewClient("test")
+	runClient := run.NewClient(client)
+
+	ctx, root := runClient.StartRun(context.Background(), "root")
+
+	_, extract := root.StartChild(ctx, "extract")
+	extract.Finish()
+
+	loadCtx, load := root.StartChild(ctx, "load")
+	_, table := load.StartChild(loadCtx, "load-table")
+	table.RecordInputs(openlineage.NewInputElement("staging.users", "postgres://db"))
+	table.Finish()
+	load.Finish()
+
+	root.Finish()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 4 START, 4 COMPLETE and 1 OTHER event
+	events, err := rec.WaitForEvents(ctx, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return events
+}
+
+func Test_Recorder_Filters(t *testing.T) {
+	events := emitTree(t)
+
+	if n := len(oltest.Select(events, oltest.ByEventType(openlineage.EventTypeStart))); n != 4 {
+		t.Errorf("%d START events, want 4", n)
+	}
+
+	loadTable := oltest.Select(events, oltest.ByJob("test", "load-table"))
+	if len(loadTable) != 3 {
+		t.Fatalf("%d events for load-table, want 3", len(loadTable))
+	}
+
+	if n := len(oltest.Select(events, oltest.ByRunID(loadTable[0].Run.RunID))); n != 3 {
+		t.Errorf("%d events for run of load-table, want 3", n)
+	}
+
+	if n := len(oltest.Select(events, oltest.ByJob("other", "load-table"))); n != 0 {
+		t.Errorf("%d events for job in other namespace, want 0", n)
+	}
+}
+
+func Test_AssertRunTree(t *testing.T) {
+	events := emitTree(t)
+
+	oltest.AssertRunTree(t, events, oltest.RunTree{
+		Job: "root",
+		Children: []oltest.RunTree{
+			{Job: "load", Children: []oltest.RunTree{{Job: "load-table"}}},
+			{Job: "extract"},
+		},
+	})
+
+	tb := &fakeTB{TB: t}
+	oltest.AssertRunTree(tb, events, oltest.RunTree{
+		Job: "root",
+		Children: []oltest.RunTree{
+			{Job: "extract"},
+			{Job: "transform"},
+		},
+	})
+
+	want := []string{
+		"root/transform: run not found",
+		"root/load: unexpected run",
+	}
+
+	if len(tb.errors) != len(want) {
+		t.Fatalf("got errors %q, want %d errors", tb.errors, len(want))
+	}
+
+	for i, w := range want {
+		if !strings.HasPrefix(tb.errors[i], w) {
+			t.Errorf("error %d = %q, want prefix %q", i, tb.errors[i], w)
+		}
+	}
+}
+
+func Test_AssertLifecycle(t *testing.T) {
+	start := openlineage.NewRunEvent(openlineage.EventTypeStart, [16]byte{1}, "job").AsEmittable()
+	complete := openlineage.NewRunEvent(openlineage.EventTypeComplete, [16]byte{1}, "job").AsEmittable()
+
+	tb := &fakeTB{TB: t}
+	oltest.AssertLifecycle(tb, []openlineage.Event{start, complete})
+	if len(tb.errors) != 0 {
+		t.Errorf("unexpected errors for valid lifecycle: %q", tb.errors)
+	}
+
+	tb = &fakeTB{TB: t}
+	oltest.AssertLifecycle(tb, []openlineage.Event{complete, start, complete})
+	if len(tb.errors) != 2 {
+		t.Errorf("got errors %q, want 2 errors", tb.errors)
+	}
+}
+
+func Test_AssertGolden(t *testing.T) {
+	oltest.AssertGolden(t, "testdata/tree.golden.json", emitTree(t))
+}
+
+func Test_ReproducibleEvents(t *testing.T) {
+	emit := func() []byte {
+		client, rec := oltest.NewClient("test",
+			openlineage.WithClock(oltest.NewClock(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Second)),
+			openlineage.WithIDGenerator(oltest.NewIDSequence()),
+		)
+
+		ctx, r := run.NewClient(client).StartRun(context.Background(), "job")
+		_, child := r.StartChild(ctx, "child")
+
+		events := rec.Events()
+		if len(events) != 2 {
+			t.Fatalf("%d events recorded, want 2", len(events))
+		}
+
+		if got := events[1].Run.RunID; got != child.RunID().String() || got != "00000000-0000-0000-0000-000000000002" {
+			t.Errorf("child run ID = %s", got)
+		}
+
+		if got := events[1].EventTime; got != "2024-07-01T00:00:01Z" {
+			t.Errorf("child event time = %s", got)
+		}
+
+		body, err := json.Marshal(events)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return body
+	}
+
+	if first, second := emit(), emit(); !bytes.Equal(first, second) {
+		t.Errorf("events differ between executions:\n%s\n%s", first, second)
+	}
+}
@@ -0,0 +1,122 @@
+// Package oltest contains utilities for testing code instrumented with OpenLineage.
+//
+// [Recorder] is a transport that stores emitted events in memory,
+// and the assertion helpers verify the recorded events.
+package oltest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/transport"
+)
+
+var _ transport.Transport = (*Recorder)(nil)
+
+// Recorder is a [transport.Transport] that stores emitted events in memory.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu     sync.Mutex
+	events []openlineage.Event
+
+	// changed is closed and replaced whenever an event is recorded
+	changed chan struct{}
+}
+
+// NewRecorder creates an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		changed: make(chan struct{}),
+	}
+}
+
+// NewClient creates an [openlineage.Client] that emits events to a new Recorder.
+func NewClient(namespace string, opts ...openlineage.ClientOption) (*openlineage.Client, *Recorder) {
+	rec := NewRecorder()
+	client := openlineage.NewClientWithTransport(openlineage.ClientConfig{
+		Namespace: namespace,
+	}, rec, opts...)
+
+	return client, rec
+}
+
+// Emit implements transport.Transport.
+func (r *Recorder) Emit(ctx context.Context, event any) error {
+	e, err := toEvent(event)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, e)
+
+	close(r.changed)
+	r.changed = make(chan struct{})
+
+	return nil
+}
+
+// Events returns all recorded events, in the order they were emitted.
+func (r *Recorder) Events() []openlineage.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]openlineage.Event(nil), r.events...)
+}
+
+// Reset removes all recorded events.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = nil
+}
+
+// WaitForEvents blocks until at least n recorded events match all filters, and returns the matching events.
+// It returns an error if ctx is done before that happens.
+func (r *Recorder) WaitForEvents(ctx context.Context, n int, filters ...Filter) ([]openlineage.Event, error) {
+	for {
+		r.mu.Lock()
+		matching := Select(r.events, filters...)
+		changed := r.changed
+		r.mu.Unlock()
+
+		if len(matching) >= n {
+			return matching, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return matching, fmt.Errorf("waiting for %d events, got %d: %w", n, len(matching), ctx.Err())
+		case <-changed:
+		}
+	}
+}
+
+func toEvent(event any) (openlineage.Event, error) {
+	switch e := event.(type) {
+	case openlineage.Event:
+		return e, nil
+	case *openlineage.Event:
+		return *e, nil
+	case openlineage.Emittable:
+		return e.AsEmittable(), nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return openlineage.Event{}, fmt.Errorf("marshal event: %w", err)
+	}
+
+	var e openlineage.Event
+	if err := json.Unmarshal(body, &e); err != nil {
+		return openlineage.Event{}, fmt.Errorf("unmarshal event: %w", err)
+	}
+
+	return e, nil
+}
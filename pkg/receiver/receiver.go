@@ -0,0 +1,252 @@
+// Package receiver provides an [http.Handler] that accepts OpenLineage events,
+// for building collectors, proxies and fan-out services.
+//
+// The handler accepts the payloads sent to the OpenLineage HTTP endpoint: a single event as a JSON object,
+// or a batch of events as a JSON array. Payloads can be gzip-compressed.
+// Decoded events are passed to a [Dispatcher], such as a callback or a [transport.Transport].
+package receiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/schema"
+	"github.com/ThijsKoot/openlineage-go/pkg/transport"
+)
+
+// DefaultMaxBodySize is the default limit on the size of a request body, after decompression.
+const DefaultMaxBodySize = 10 << 20
+
+// Dispatcher processes received events.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, events []openlineage.Event) error
+}
+
+// DispatcherFunc adapts a function to a [Dispatcher].
+type DispatcherFunc func(ctx context.Context, events []openlineage.Event) error
+
+// Dispatch implements Dispatcher.
+func (f DispatcherFunc) Dispatch(ctx context.Context, events []openlineage.Event) error {
+	return f(ctx, events)
+}
+
+// TransportDispatcher returns a Dispatcher that emits every event using t.
+// All events are emitted, even if some fail, and the errors are returned together.
+func TransportDispatcher(t transport.Transport) Dispatcher {
+	return DispatcherFunc(func(ctx context.Context, events []openlineage.Event) error {
+		var errs []error
+		for _, e := range events {
+			if err := t.Emit(ctx, e); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+type config struct {
+	apiKey         string
+	validate       bool
+	validateSchema bool
+	maxBodySize    int64
+}
+
+// Option configures a [Handler].
+type Option func(*config)
+
+// WithAPIKey requires requests to carry apiKey as a bearer token in the Authorization header.
+func WithAPIKey(apiKey string) Option {
+	return func(c *config) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithStructuralValidation rejects payloads containing events that fail [openlineage.ValidateEvent].
+// Events are checked for the fields required by the specification, not against its JSON schema; see [WithSchemaValidation].
+func WithStructuralValidation() Option {
+	return func(c *config) {
+		c.validate = true
+	}
+}
+
+// WithSchemaValidation rejects payloads containing events that do not match the JSON schema of the specification,
+// see package [schema]. It can be combined with [WithStructuralValidation], which also checks timestamps and UUIDs.
+func WithSchemaValidation() Option {
+	return func(c *config) {
+		c.validateSchema = true
+	}
+}
+
+// WithMaxBodySize limits the size of request bodies, after decompression (default: [DefaultMaxBodySize]).
+func WithMaxBodySize(n int64) Option {
+	return func(c *config) {
+		c.maxBodySize = n
+	}
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// Handler receives OpenLineage events over HTTP.
+//
+// It responds with:
+//   - 201 Created if all events were dispatched,
+//   - 400 Bad Request if the payload could not be decoded or failed validation,
+//   - 401 Unauthorized if the bearer token does not match the API key,
+//   - 405 Method Not Allowed for methods other than POST,
+//   - 413 Request Entity Too Large if the body exceeds the size limit,
+//   - 415 Unsupported Media Type for content encodings other than gzip,
+//   - 500 Internal Server Error if the Dispatcher returned an error.
+type Handler struct {
+	cfg        config
+	dispatcher Dispatcher
+}
+
+// NewHandler creates a Handler passing received events to dispatcher.
+func NewHandler(dispatcher Dispatcher, opts ...Option) *Handler {
+	cfg := config{
+		maxBodySize: DefaultMaxBodySize,
+	}
+
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	return &Handler{
+		cfg:        cfg,
+		dispatcher: dispatcher,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	body, status, err := h.readBody(r)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	if h.cfg.validateSchema {
+		if err := schema.Validate(body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	events, err := Decode(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if h.cfg.validate {
+		if err := validate(events); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if len(events) > 0 {
+		if err := h.dispatcher.Dispatch(r.Context(), events); err != nil {
+			http.Error(w, fmt.Sprintf("dispatch events: %s", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// Authorized reports whether r carries the API key as a bearer token, or no API key is required.
+// The token is compared in constant time.
+func (h *Handler) Authorized(r *http.Request) bool {
+	if h.cfg.apiKey == "" {
+		return true
+	}
+
+	return subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+h.cfg.apiKey)) == 1
+}
+
+// readBody reads the request body, decompressing it if needed.
+// On failure, it returns the status code to respond with.
+func (h *Handler) readBody(r *http.Request) ([]byte, int, error) {
+	var body io.Reader = r.Body
+
+	switch r.Header.Get("Content-Encoding") {
+	case "", "identity":
+	case "gzip":
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("read gzip body: %w", err)
+		}
+		defer gz.Close()
+
+		body = gz
+	default:
+		return nil, http.StatusUnsupportedMediaType, fmt.Errorf("unsupported content encoding %q", r.Header.Get("Content-Encoding"))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, h.cfg.maxBodySize+1))
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("read body: %w", err)
+	}
+
+	if int64(len(data)) > h.cfg.maxBodySize {
+		return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("body exceeds %d bytes", h.cfg.maxBodySize)
+	}
+
+	return data, 0, nil
+}
+
+// Decode decodes an OpenLineage payload, which is either a single event or a JSON array of events.
+func Decode(payload []byte) ([]openlineage.Event, error) {
+	payload = bytes.TrimSpace(payload)
+	if len(payload) == 0 {
+		return nil, errors.New("empty payload")
+	}
+
+	if payload[0] == '[' {
+		var events []openlineage.Event
+		if err := json.Unmarshal(payload, &events); err != nil {
+			return nil, fmt.Errorf("decode batch: %w", err)
+		}
+
+		return events, nil
+	}
+
+	var event openlineage.Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("decode event: %w", err)
+	}
+
+	return []openlineage.Event{event}, nil
+}
+
+func validate(events []openlineage.Event) error {
+	var errs []error
+	for i, e := range events {
+		if err := openlineage.ValidateEvent(e); err != nil {
+			errs = append(errs, fmt.Errorf("event %d: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
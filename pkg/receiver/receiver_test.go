@@ -0,0 +1,256 @@
+package receiver_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
+	"github.com/ThijsKoot/openlineage-go/pkg/oltest"
+	"github.com/ThijsKoot/openlineage-go/pkg/receiver"
+	"github.com/google/uuid"
+)
+
+func newEvent(eventType openlineage.EventType) openlineage.Event {
+	return openlineage.NewRunEvent(eventType, uuid.Must(uuid.NewV7()), "job").AsEmittable()
+}
+
+func encode(t *testing.T, v any) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func compress(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func Test_Handler(t *testing.T) {
+	single := encode(t, newEvent(openlineage.EventTypeStart))
+	batch := encode(t, []openlineage.Event{newEvent(openlineage.EventTypeStart), newEvent(openlineage.EventTypeComplete)})
+	invalid := encode(t, map[string]any{"eventTime": "now", "run": map[string]any{"runId": "123"}})
+
+	// passes structural validation, but the facet lacks the _schemaURL required by the schema
+	noSchemaURL := newEvent(openlineage.EventTypeStart)
+	noSchemaURL.Run.Facets = &facets.RunFacets{Custom: map[string]any{"custom": map[string]any{"_producer": "test"}}}
+	schemaInvalid := encode(t, []openlineage.Event{newEvent(openlineage.EventTypeStart), noSchemaURL})
+
+	tests := []struct {
+		name       string
+		method     string
+		body       []byte
+		headers    map[string]string
+		opts       []receiver.Option
+		dispatch   error
+		wantStatus int
+		wantEvents int
+	}{
+		{
+			name:       "single",
+			body:       single,
+			wantStatus: http.StatusCreated,
+			wantEvents: 1,
+		},
+		{
+			name:       "batch",
+			body:       batch,
+			wantStatus: http.StatusCreated,
+			wantEvents: 2,
+		},
+		{
+			name:       "empty-batch",
+			body:       []byte(" [] "),
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "gzip",
+			body:       compress(t, batch),
+			headers:    map[string]string{"Content-Encoding": "gzip"},
+			wantStatus: http.StatusCreated,
+			wantEvents: 2,
+		},
+		{
+			name:       "unsupported-encoding",
+			body:       batch,
+			headers:    map[string]string{"Content-Encoding": "br"},
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:       "corrupt-gzip",
+			body:       batch,
+			headers:    map[string]string{"Content-Encoding": "gzip"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "authorized",
+			body:       single,
+			headers:    map[string]string{"Authorization": "Bearer secret"},
+			opts:       []receiver.Option{receiver.WithAPIKey("secret")},
+			wantStatus: http.StatusCreated,
+			wantEvents: 1,
+		},
+		{
+			name:       "unauthorized",
+			body:       single,
+			headers:    map[string]string{"Authorization": "Bearer wrong"},
+			opts:       []receiver.Option{receiver.WithAPIKey("secret")},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unauthorized-prefix",
+			body:       single,
+			headers:    map[string]string{"Authorization": "Bearer secre"},
+			opts:       []receiver.Option{receiver.WithAPIKey("secret")},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "missing-token",
+			body:       single,
+			opts:       []receiver.Option{receiver.WithAPIKey("secret")},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "malformed",
+			body:       []byte(`{"eventTime":`),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "empty",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid-without-validation",
+			body:       invalid,
+			wantStatus: http.StatusCreated,
+			wantEvents: 1,
+		},
+		{
+			name:       "invalid-with-validation",
+			body:       invalid,
+			opts:       []receiver.Option{receiver.WithStructuralValidation()},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "valid-with-schema-validation",
+			body:       batch,
+			opts:       []receiver.Option{receiver.WithSchemaValidation()},
+			wantStatus: http.StatusCreated,
+			wantEvents: 2,
+		},
+		{
+			name:       "schema-invalid-with-structural-validation",
+			body:       schemaInvalid,
+			opts:       []receiver.Option{receiver.WithStructuralValidation()},
+			wantStatus: http.StatusCreated,
+			wantEvents: 2,
+		},
+		{
+			name:       "schema-invalid-with-schema-validation",
+			body:       schemaInvalid,
+			opts:       []receiver.Option{receiver.WithSchemaValidation()},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid-with-schema-validation",
+			body:       invalid,
+			opts:       []receiver.Option{receiver.WithSchemaValidation()},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "too-large",
+			body:       batch,
+			opts:       []receiver.Option{receiver.WithMaxBodySize(int64(len(batch) - 1))},
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "dispatch-error",
+			body:       single,
+			dispatch:   errors.New("backend unavailable"),
+			wantStatus: http.StatusInternalServerError,
+			wantEvents: 1,
+		},
+		{
+			name:       "method-not-allowed",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received []openlineage.Event
+			dispatcher := receiver.DispatcherFunc(func(_ context.Context, events []openlineage.Event) error {
+				received = append(received, events...)
+				return tt.dispatch
+			})
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+
+			req := httptest.NewRequest(method, "/api/v1/lineage", bytes.NewReader(tt.body))
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			receiver.NewHandler(dispatcher, tt.opts...).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.wantStatus, strings.TrimSpace(rec.Body.String()))
+			}
+
+			if len(received) != tt.wantEvents {
+				t.Errorf("dispatched %d events, want %d", len(received), tt.wantEvents)
+			}
+		})
+	}
+}
+
+func Test_TransportDispatcher(t *testing.T) {
+	rec := oltest.NewRecorder()
+	srv := httptest.NewServer(receiver.NewHandler(receiver.TransportDispatcher(rec)))
+	defer srv.Close()
+
+	start, complete := newEvent(openlineage.EventTypeStart), newEvent(openlineage.EventTypeComplete)
+
+	resp, err := http.Post(srv.URL, "application/json", bytes.NewReader(encode(t, []openlineage.Event{start, complete})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+
+	events := rec.Events()
+	if len(events) != 2 || events[0].Run.RunID != start.Run.RunID || events[1].Run.RunID != complete.Run.RunID {
+		t.Errorf("recorder did not receive the events in order: %+v", events)
+	}
+}
@@ -2,35 +2,90 @@ package run
 
 import (
 	"context"
+	"sync"
 
 	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
 	"github.com/google/uuid"
 )
 
-func NewClient(client *openlineage.Client) *Client {
-	return &Client{
+func NewClient(client *openlineage.Client, opts ...ClientOption) *Client {
+	c := &Client{
 		olc: client,
 	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
 }
 
 type Client struct {
-	olc *openlineage.Client
+	olc           *openlineage.Client
+	hooks         []Hook
+	runIDStrategy RunIDStrategy
+
+	// pending tracks events that are being emitted asynchronously, see Flush
+	pending pending
+}
+
+// ClientOption configures a [Client].
+type ClientOption func(*Client)
+
+// WithHooks adds hooks that are called during the lifecycle of every Run created by the Client.
+func WithHooks(hooks ...Hook) ClientOption {
+	return func(c *Client) {
+		c.hooks = append(c.hooks, hooks...)
+	}
 }
 
 // NewRun creates a Run.
 // If ctx already contains a RunContext, it set as the parent.
+// This includes runs from other processes added to ctx with [Extract].
+// Otherwise, a run propagated through the environment of this process (see [EnvCarrier]) is used as the parent.
+// The ID of the Run is generated by the Client's [RunIDStrategy], unless [WithRunID] is supplied.
+// Without a strategy, the [openlineage.IDGenerator] of the underlying [openlineage.Client] is used.
 // The resulting Run is stored in ctx using [ContextWithRun].
-func (c *Client) NewRun(ctx context.Context, job string) (context.Context, Run) {
+func (c *Client) NewRun(ctx context.Context, job string, opts ...RunOption) (context.Context, Run) {
+	var cfg runConfig
+	for _, o := range opts {
+		o(&cfg)
+	}
+
 	r := run{
 		client:       c,
-		runID:        uuid.Must(uuid.NewV7()),
 		jobName:      job,
 		jobNamespace: c.olc.Namespace,
+		runFacets:    cfg.runFacets,
+	}
+
+	switch {
+	case cfg.runID != nil:
+		r.runID = *cfg.runID
+	case c.runIDStrategy != nil:
+		r.runID = c.runIDStrategy(r.jobNamespace, r.jobName, cfg.key)
+	}
+
+	if r.runID == uuid.Nil {
+		r.runID = c.olc.NewRunID()
 	}
 
 	parent := FromContext(ctx)
 	if _, isNoop := parent.(*noopRun); !isNoop {
 		r.parent = parent
+	} else {
+		r.parent = environmentParent()
+	}
+
+	if r.parent != nil {
+		r.root = r.parent.Root()
+	}
+
+	for _, h := range c.hooks {
+		var runFacets []facets.RunFacet
+		ctx, runFacets = h.OnNewRun(ctx, &r)
+		r.runFacets = append(r.runFacets, runFacets...)
 	}
 
 	return ContextWithRun(ctx, &r), &r
@@ -38,8 +93,8 @@ func (c *Client) NewRun(ctx context.Context, job string) (context.Context, Run)
 
 // StartRun calls NewRun and emits a START event.
 // For details, see NewRun.
-func (c *Client) StartRun(ctx context.Context, job string) (context.Context, Run) {
-	ctx, r := c.NewRun(ctx, job)
+func (c *Client) StartRun(ctx context.Context, job string, opts ...RunOption) (context.Context, Run) {
+	ctx, r := c.NewRun(ctx, job, opts...)
 
 	startEvent := r.NewEvent(openlineage.EventTypeStart)
 	_ = c.Emit(ctx, startEvent)
@@ -64,14 +119,61 @@ func (c *Client) Emit(ctx context.Context, event openlineage.Emittable) error {
 	return c.olc.Emit(ctx, event)
 }
 
+// Flush blocks until all events that runs of the Client emit asynchronously have been handled, or until ctx is done.
+// Use it before the program exits, or in tests before inspecting emitted events.
+func (c *Client) Flush(ctx context.Context) error {
+	return c.pending.wait(ctx)
+}
+
+// pending counts events that are being emitted asynchronously.
+type pending struct {
+	mu sync.Mutex
+	n  int
+
+	// idle is closed when n drops to zero
+	idle chan struct{}
+}
+
+func (p *pending) add(delta int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.n == 0 {
+		p.idle = make(chan struct{})
+	}
+
+	p.n += delta
+	if p.n == 0 {
+		close(p.idle)
+	}
+}
+
+func (p *pending) wait(ctx context.Context) error {
+	p.mu.Lock()
+	if p.n == 0 {
+		p.mu.Unlock()
+		return nil
+	}
+
+	idle := p.idle
+	p.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // New calls [Client.New] using [openlineage.DefaultClient].
-func New(ctx context.Context, job string) (context.Context, Run) {
-	return NewClient(openlineage.DefaultClient).NewRun(ctx, job)
+func New(ctx context.Context, job string, opts ...RunOption) (context.Context, Run) {
+	return NewClient(openlineage.DefaultClient).NewRun(ctx, job, opts...)
 }
 
 // Start calls [Client.Start] using [openlineage.DefaultClient].
-func Start(ctx context.Context, job string) (context.Context, Run) {
-	return NewClient(openlineage.DefaultClient).StartRun(ctx, job)
+func Start(ctx context.Context, job string, opts ...RunOption) (context.Context, Run) {
+	return NewClient(openlineage.DefaultClient).StartRun(ctx, job, opts...)
 }
 
 // Existing calls [Client.ExistingRun] using [openlineage.DefaultClient].
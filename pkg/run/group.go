@@ -0,0 +1,83 @@
+package run
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Group runs functions concurrently as child Runs of the Run in its context.
+// It wraps [errgroup.Group]: the first error returned by a function cancels the Group's context and is returned by [Group.Wait].
+type Group struct {
+	eg     *errgroup.Group
+	ctx    context.Context
+	parent Run
+
+	failParent bool
+}
+
+// GroupOption configures a [Group].
+type GroupOption func(*Group)
+
+// WithFailParent marks the parent Run as failed when any of the Group's functions returns an error.
+func WithFailParent() GroupOption {
+	return func(g *Group) {
+		g.failParent = true
+	}
+}
+
+// WithLimit limits the number of functions that are active at the same time.
+// See [errgroup.Group.SetLimit].
+func WithLimit(n int) GroupOption {
+	return func(g *Group) {
+		g.eg.SetLimit(n)
+	}
+}
+
+// NewGroup creates a Group whose functions are started as children of the Run in ctx.
+// If ctx contains no Run, the functions are executed without creating Runs.
+// The returned context is canceled when a function returns an error or Wait returns, whichever occurs first.
+func NewGroup(ctx context.Context, opts ...GroupOption) (*Group, context.Context) {
+	eg, ctx := errgroup.WithContext(ctx)
+
+	g := &Group{
+		eg:     eg,
+		ctx:    ctx,
+		parent: FromContext(ctx),
+	}
+
+	for _, o := range opts {
+		o(g)
+	}
+
+	return g, ctx
+}
+
+// Go starts a child Run for jobName and calls f in a new goroutine with the child's context.
+// The child Run is finished when f returns, and fails if f returns an error.
+// If a limit was set, Go blocks until f can be started.
+func (g *Group) Go(jobName string, f func(ctx context.Context) error) {
+	g.eg.Go(func() error {
+		ctx, child := g.parent.StartChild(g.ctx, jobName)
+
+		err := f(ctx)
+		if err != nil {
+			child.RecordError(err)
+		}
+
+		child.Finish()
+
+		return err
+	})
+}
+
+// Wait blocks until all functions have returned, and returns the first error returned by any of them.
+// If [WithFailParent] was set and an error occurred, it is recorded on the parent Run.
+func (g *Group) Wait() error {
+	err := g.eg.Wait()
+	if err != nil && g.failParent {
+		g.parent.RecordError(err)
+	}
+
+	return err
+}
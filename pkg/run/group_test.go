@@ -0,0 +1,121 @@
+package run_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+)
+
+func Test_Group(t *testing.T) {
+	cases := []struct {
+		name           string
+		opts           []run.GroupOption
+		failing        string
+		wantParentFail bool
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:    "child-fails",
+			failing: "b",
+		},
+		{
+			name:           "child-fails-parent",
+			opts:           []run.GroupOption{run.WithFailParent()},
+			failing:        "b",
+			wantParentFail: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient()
+			ctx, parent := client.NewRun(context.Background(), "parent")
+
+			var mu sync.Mutex
+			children := map[string]run.Run{}
+
+			g, ctx := run.NewGroup(ctx, tt.opts...)
+			for _, job := range []string{"a", "b", "c"} {
+				g.Go(job, func(ctx context.Context) error {
+					mu.Lock()
+					children[job] = run.FromContext(ctx)
+					mu.Unlock()
+
+					if job == tt.failing {
+						return errors.New("failed")
+					}
+
+					return nil
+				})
+			}
+
+			err := g.Wait()
+			if (err != nil) != (tt.failing != "") {
+				t.Errorf("Wait() = %v, want error: %v", err, tt.failing != "")
+			}
+
+			for job, child := range children {
+				if child.JobName() != job {
+					t.Errorf("child job name = %q, want %q", child.JobName(), job)
+				}
+
+				if child.Parent() == nil || child.Parent().RunID() != parent.RunID() {
+					t.Errorf("child %s is not a child of the parent run", job)
+				}
+
+				if child.HasFailed() != (job == tt.failing) {
+					t.Errorf("child %s HasFailed() = %v", job, child.HasFailed())
+				}
+			}
+
+			if parent.HasFailed() != tt.wantParentFail {
+				t.Errorf("parent HasFailed() = %v, want %v", parent.HasFailed(), tt.wantParentFail)
+			}
+
+			if tt.failing != "" && ctx.Err() == nil {
+				t.Error("group context was not canceled")
+			}
+		})
+	}
+}
+
+func Test_Group_Limit(t *testing.T) {
+	client, _ := newTestClient()
+	ctx, _ := client.NewRun(context.Background(), "parent")
+
+	var active, maxActive atomic.Int32
+
+	g, _ := run.NewGroup(ctx, run.WithLimit(2))
+	for range 6 {
+		g.Go("child", func(ctx context.Context) error {
+			n := active.Add(1)
+			defer active.Add(-1)
+
+			for {
+				m := maxActive.Load()
+				if n <= m || maxActive.CompareAndSwap(m, n) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait() = %v", err)
+	}
+
+	if m := maxActive.Load(); m > 2 {
+		t.Errorf("%d functions were active at the same time, want at most 2", m)
+	}
+}
@@ -0,0 +1,26 @@
+module github.com/ThijsKoot/openlineage-go/pkg/run/grpcrun
+
+go 1.22.4
+
+require (
+	github.com/ThijsKoot/openlineage-go v0.0.0-00010101000000-000000000000
+	google.golang.org/grpc v1.65.0
+)
+
+require (
+	github.com/go-stack/stack v1.8.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/hashicorp/go-cleanhttp v0.5.2 // indirect
+	github.com/hashicorp/go-retryablehttp v0.7.7 // indirect
+	github.com/sethvargo/go-envconfig v1.1.0 // indirect
+	github.com/tidwall/pretty v1.2.1 // indirect
+	golang.org/x/net v0.27.0 // indirect
+	golang.org/x/sync v0.7.0 // indirect
+	golang.org/x/sys v0.22.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240528184218-531527333157 // indirect
+	google.golang.org/protobuf v1.34.2 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/ThijsKoot/openlineage-go => ../../..
@@ -0,0 +1,138 @@
+// Package grpcrun provides gRPC instrumentation for the run package.
+//
+// Server interceptors start a Run for every RPC they handle,
+// using runs propagated through incoming metadata as their parents.
+// Client interceptors propagate the Run in the call's context to the server.
+package grpcrun
+
+import (
+	"context"
+
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type config struct {
+	jobName func(fullMethod string) string
+}
+
+// Option configures the server interceptors.
+type Option func(*config)
+
+// WithJobNameFormatter sets the function used to derive the job name from the full RPC method name.
+// By default, the full method name (e.g. "/package.Service/Method") is used as-is.
+func WithJobNameFormatter(f func(fullMethod string) string) Option {
+	return func(c *config) {
+		c.jobName = f
+	}
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{
+		jobName: func(fullMethod string) string {
+			return fullMethod
+		},
+	}
+
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	return cfg
+}
+
+// UnaryServerInterceptor returns an interceptor that handles each unary RPC within a Run.
+// The Run is stored in the context passed to the handler, so it can be retrieved using [run.FromContext].
+// RPCs returning an error, i.e. a non-OK status, are recorded as failed.
+func UnaryServerInterceptor(client *run.Client, opts ...Option) grpc.UnaryServerInterceptor {
+	cfg := newConfig(opts)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, r := startRun(ctx, client, cfg.jobName(info.FullMethod))
+
+		resp, err := handler(ctx, req)
+		finish(r, err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns an interceptor that handles each streaming RPC within a Run.
+// The Run is stored in the stream's context, so it can be retrieved using [run.FromContext].
+// RPCs returning an error, i.e. a non-OK status, are recorded as failed.
+func StreamServerInterceptor(client *run.Client, opts ...Option) grpc.StreamServerInterceptor {
+	cfg := newConfig(opts)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, r := startRun(ss.Context(), client, cfg.jobName(info.FullMethod))
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		finish(r, err)
+
+		return err
+	}
+}
+
+// UnaryClientInterceptor returns an interceptor that propagates the Run in the call's context to the server.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(inject(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor returns an interceptor that propagates the Run in the stream's context to the server.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(inject(ctx), desc, cc, method, opts...)
+	}
+}
+
+func startRun(ctx context.Context, client *run.Client, jobName string) (context.Context, run.Run) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		carrier := run.MetadataCarrier{}
+		for k, v := range md {
+			if len(v) > 0 {
+				carrier[k] = v[0]
+			}
+		}
+
+		ctx = run.Extract(ctx, carrier)
+	}
+
+	return client.StartRun(ctx, jobName)
+}
+
+func finish(r run.Run, err error) {
+	if err != nil {
+		r.RecordError(err)
+	}
+
+	r.Finish()
+}
+
+func inject(ctx context.Context) context.Context {
+	carrier := run.MetadataCarrier{}
+	run.Inject(ctx, carrier)
+
+	if len(carrier) == 0 {
+		return ctx
+	}
+
+	kv := make([]string, 0, len(carrier)*2)
+	for k, v := range carrier {
+		kv = append(kv, k, v)
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
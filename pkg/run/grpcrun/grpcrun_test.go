@@ -0,0 +1,198 @@
+package grpcrun_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/oltest"
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+	"github.com/ThijsKoot/openlineage-go/pkg/run/grpcrun"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// healthServer records the Run of the last call it handled.
+// Requests for the "fail" service return an error.
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	mu   sync.Mutex
+	last run.Run
+}
+
+func (s *healthServer) record(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.last = run.FromContext(ctx)
+}
+
+func (s *healthServer) lastRun() run.Run {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.last
+}
+
+func (s *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	s.record(ctx)
+
+	if req.Service == "fail" {
+		return nil, status.Error(codes.Unavailable, "service unavailable")
+	}
+
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+func (s *healthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	s.record(stream.Context())
+
+	if req.Service == "fail" {
+		return status.Error(codes.Internal, "watch failed")
+	}
+
+	return stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING})
+}
+
+func setup(t *testing.T, client *run.Client) (healthpb.HealthClient, *healthServer) {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	hs := &healthServer{}
+
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(grpcrun.UnaryServerInterceptor(client)),
+		grpc.StreamInterceptor(grpcrun.StreamServerInterceptor(client)),
+	)
+	healthpb.RegisterHealthServer(srv, hs)
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(grpcrun.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(grpcrun.StreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatalf("create client connection: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return healthpb.NewHealthClient(conn), hs
+}
+
+func Test_Interceptors(t *testing.T) {
+	cases := []struct {
+		name    string
+		service string
+		stream  bool
+		wantJob string
+		// wantError is the message of the ErrorMessage facet of the FAIL event, if the RPC fails
+		wantError string
+	}{
+		{
+			name:    "unary-ok",
+			wantJob: "/grpc.health.v1.Health/Check",
+		},
+		{
+			name:      "unary-error",
+			service:   "fail",
+			wantJob:   "/grpc.health.v1.Health/Check",
+			wantError: "rpc error: code = Unavailable desc = service unavailable",
+		},
+		{
+			name:    "stream-ok",
+			stream:  true,
+			wantJob: "/grpc.health.v1.Health/Watch",
+		},
+		{
+			name:      "stream-error",
+			service:   "fail",
+			stream:    true,
+			wantJob:   "/grpc.health.v1.Health/Watch",
+			wantError: "rpc error: code = Internal desc = watch failed",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			olc, rec := oltest.NewClient("test")
+			client := run.NewClient(olc)
+			hc, hs := setup(t, client)
+
+			ctx, parent := client.NewRun(context.Background(), "caller")
+			req := &healthpb.HealthCheckRequest{Service: tt.service}
+
+			var err error
+			if tt.stream {
+				var stream healthpb.Health_WatchClient
+				stream, err = hc.Watch(ctx, req)
+				if err == nil {
+					for err == nil {
+						_, err = stream.Recv()
+					}
+				}
+			} else {
+				_, err = hc.Check(ctx, req)
+			}
+
+			if tt.wantError != "" && status.Code(err) == codes.OK {
+				t.Fatal("expected call to fail")
+			}
+
+			got := hs.lastRun()
+			if got == nil {
+				t.Fatal("handler was not called")
+			}
+
+			if got.JobName() != tt.wantJob {
+				t.Errorf("job name = %q, want %q", got.JobName(), tt.wantJob)
+			}
+
+			if got.Parent() == nil || got.Parent().RunID() != parent.RunID() {
+				t.Errorf("run does not have caller %s as parent", parent.RunID())
+			}
+
+			if err := client.Flush(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			events := oltest.Select(rec.Events(), oltest.ByRunID(got.RunID().String()))
+
+			if tt.wantError == "" {
+				if n := len(oltest.Select(events, oltest.ByEventType(openlineage.EventTypeComplete))); n != 1 {
+					t.Errorf("%d COMPLETE events, want 1", n)
+				}
+
+				return
+			}
+
+			fail := oltest.Select(events, oltest.ByEventType(openlineage.EventTypeFail))
+			if len(fail) != 1 {
+				t.Fatalf("%d FAIL events, want 1", len(fail))
+			}
+
+			if fail[0].Run.Facets == nil || fail[0].Run.Facets.ErrorMessage == nil {
+				t.Fatal("FAIL event has no ErrorMessage facet")
+			}
+
+			if got := fail[0].Run.Facets.ErrorMessage.Message; got != tt.wantError {
+				t.Errorf("error message = %q, want %q", got, tt.wantError)
+			}
+		})
+	}
+}
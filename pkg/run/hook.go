@@ -0,0 +1,18 @@
+package run
+
+import (
+	"context"
+
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
+)
+
+// Hook observes the lifecycle of Runs created by a [Client].
+// It can be used to integrate Runs with other instrumentation, such as tracing.
+type Hook interface {
+	// OnNewRun is called when a Run is created by [Client.NewRun], before any event is emitted for it.
+	// The returned context takes the place of ctx, and the returned facets are included in every event of the Run.
+	OnNewRun(ctx context.Context, r Run) (context.Context, []facets.RunFacet)
+
+	// OnFinish is called when [Run.Finish] is called, after the final event has been created.
+	OnFinish(r Run)
+}
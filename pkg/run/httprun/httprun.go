@@ -0,0 +1,138 @@
+// Package httprun provides net/http instrumentation for the run package.
+//
+// The middleware starts a Run for every request it handles,
+// using runs propagated through request headers as their parents.
+// The RoundTripper propagates the Run in a request's context to the server it calls.
+package httprun
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+)
+
+type config struct {
+	jobName func(route string, r *http.Request) string
+}
+
+// Option configures the middleware.
+type Option func(*config)
+
+// WithJobNameFormatter sets the function used to derive the job name from the route template and request.
+// By default, the route template is used as-is.
+func WithJobNameFormatter(f func(route string, r *http.Request) string) Option {
+	return func(c *config) {
+		c.jobName = f
+	}
+}
+
+// NewHandler wraps handler so that each request is handled within a Run.
+// The job name is taken from route, which is expected to be a route template such as "GET /users/{id}".
+//
+// The Run is stored in the request context, so handlers can retrieve it using [run.FromContext].
+// Responses with a 5xx status code and panics are recorded as errors, causing the Run to fail.
+func NewHandler(client *run.Client, route string, handler http.Handler, opts ...Option) http.Handler {
+	cfg := config{
+		jobName: func(route string, _ *http.Request) string {
+			return route
+		},
+	}
+
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := run.Extract(req.Context(), run.HeaderCarrier(req.Header))
+		ctx, r := client.StartRun(ctx, cfg.jobName(route, req))
+
+		rw := &responseWriter{
+			ResponseWriter: w,
+			status:         http.StatusOK,
+		}
+
+		defer func() {
+			if p := recover(); p != nil {
+				r.RecordError(fmt.Errorf("panic while handling request: %v", p))
+				r.Finish()
+
+				panic(p)
+			}
+
+			if rw.status >= http.StatusInternalServerError {
+				r.RecordError(fmt.Errorf("responded with status %d", rw.status))
+			}
+
+			r.Finish()
+		}()
+
+		handler.ServeHTTP(rw, req.WithContext(ctx))
+	})
+}
+
+// Middleware returns a function that wraps handlers using [NewHandler].
+func Middleware(client *run.Client, route string, opts ...Option) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return NewHandler(client, route, h, opts...)
+	}
+}
+
+// responseWriter records the status code written by a handler.
+type responseWriter struct {
+	http.ResponseWriter
+
+	status      int
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.status = statusCode
+		w.wroteHeader = true
+	}
+
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap allows [http.ResponseController] to access the underlying ResponseWriter.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+var _ http.RoundTripper = (*Transport)(nil)
+
+// Transport is an [http.RoundTripper] that propagates the Run in the request context
+// to the server using HTTP headers.
+type Transport struct {
+	// Base is the RoundTripper used to make requests.
+	// If nil, [http.DefaultTransport] is used.
+	Base http.RoundTripper
+}
+
+// NewTransport creates a Transport wrapping base.
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{
+		Base: base,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	// RoundTrippers must not modify the original request
+	req = req.Clone(req.Context())
+	run.Inject(req.Context(), run.HeaderCarrier(req.Header))
+
+	return base.RoundTrip(req)
+}
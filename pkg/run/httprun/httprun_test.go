@@ -0,0 +1,143 @@
+package httprun_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/oltest"
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+	"github.com/ThijsKoot/openlineage-go/pkg/run/httprun"
+)
+
+func Test_Handler(t *testing.T) {
+	cases := []struct {
+		name    string
+		handler http.HandlerFunc
+		// wantError is the message of the ErrorMessage facet of the FAIL event, if the run fails
+		wantError string
+	}{
+		{
+			name:    "ok",
+			handler: func(w http.ResponseWriter, r *http.Request) {},
+		},
+		{
+			name: "client-error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+		},
+		{
+			name: "server-error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			wantError: "responded with status 502",
+		},
+		{
+			name: "panic",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				panic("boom")
+			},
+			wantError: "panic while handling request: boom",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			olc, rec := oltest.NewClient("test")
+			client := run.NewClient(olc)
+
+			var got run.Run
+			handler := httprun.NewHandler(client, "GET /items/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = run.FromContext(r.Context())
+				tt.handler(w, r)
+			}))
+
+			srv := httptest.NewServer(handler)
+			defer srv.Close()
+
+			ctx, parent := client.NewRun(context.Background(), "caller")
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/items/1", nil)
+			if err != nil {
+				t.Fatalf("create request: %s", err)
+			}
+
+			httpClient := &http.Client{Transport: httprun.NewTransport(nil)}
+			resp, err := httpClient.Do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+
+			if got == nil {
+				t.Fatal("handler was not called")
+			}
+
+			if got.JobName() != "GET /items/{id}" {
+				t.Errorf("job name = %q, want %q", got.JobName(), "GET /items/{id}")
+			}
+
+			if got.Parent() == nil || got.Parent().RunID() != parent.RunID() {
+				t.Errorf("run does not have caller %s as parent", parent.RunID())
+			}
+
+			if err := client.Flush(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			events := oltest.Select(rec.Events(), oltest.ByRunID(got.RunID().String()))
+
+			start := oltest.Select(events, oltest.ByEventType(openlineage.EventTypeStart))
+			if len(start) != 1 || start[0].Run.Facets == nil || start[0].Run.Facets.Parent == nil {
+				t.Fatal("no START event with a parent facet")
+			}
+
+			if start[0].Run.Facets.Parent.Run.RunID != parent.RunID().String() {
+				t.Errorf("parent facet run ID = %s, want %s", start[0].Run.Facets.Parent.Run.RunID, parent.RunID())
+			}
+
+			if tt.wantError == "" {
+				if n := len(oltest.Select(events, oltest.ByEventType(openlineage.EventTypeComplete))); n != 1 {
+					t.Errorf("%d COMPLETE events, want 1", n)
+				}
+
+				return
+			}
+
+			fail := oltest.Select(events, oltest.ByEventType(openlineage.EventTypeFail))
+			if len(fail) != 1 || fail[0].Run.Facets == nil || fail[0].Run.Facets.ErrorMessage == nil {
+				t.Fatal("no FAIL event with an ErrorMessage facet")
+			}
+
+			if got := fail[0].Run.Facets.ErrorMessage.Message; got != tt.wantError {
+				t.Errorf("error message = %q, want %q", got, tt.wantError)
+			}
+		})
+	}
+}
+
+func Test_Transport_DoesNotModifyRequest(t *testing.T) {
+	olc, _ := oltest.NewClient("test")
+	ctx, _ := run.NewClient(olc).NewRun(context.Background(), "caller")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatalf("create request: %s", err)
+	}
+
+	resp, err := httprun.NewTransport(nil).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip failed: %s", err)
+	}
+	resp.Body.Close()
+
+	if len(req.Header) != 0 {
+		t.Errorf("original request headers were modified: %v", req.Header)
+	}
+}
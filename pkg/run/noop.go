@@ -25,12 +25,12 @@ func (n *noopRun) RecordInputs(...openlineage.InputElement) {}
 func (n *noopRun) RecordOutputs(...openlineage.OutputElement) {}
 
 // NewChild implements RunContext.
-func (n *noopRun) NewChild(ctx context.Context, jobName string) (context.Context, Run) {
+func (n *noopRun) NewChild(ctx context.Context, jobName string, opts ...RunOption) (context.Context, Run) {
 	return ctx, &noopRun{}
 }
 
 // StartChild implements RunContext.
-func (n *noopRun) StartChild(ctx context.Context, jobName string) (context.Context, Run) {
+func (n *noopRun) StartChild(ctx context.Context, jobName string, opts ...RunOption) (context.Context, Run) {
 	return ctx, &noopRun{}
 }
 
@@ -73,6 +73,11 @@ func (n *noopRun) Parent() Run {
 	return &noopRun{}
 }
 
+// Root implements Run.
+func (n *noopRun) Root() Run {
+	return n
+}
+
 // RunID implements RunContext.
 func (n *noopRun) RunID() uuid.UUID {
 	empty := bytes.Repeat([]byte{0}, 16)
@@ -0,0 +1,116 @@
+// Package otelrun links Runs to OpenTelemetry traces.
+//
+// The [Hook] in this package records the active span of a Run as a run facet,
+// and can optionally start a span for every Run.
+// This allows lineage and traces to be joined in observability tooling.
+package otelrun
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// FacetName is the name of the run facet containing the trace context.
+	FacetName = "otelTrace"
+
+	traceFacetSchemaURL = "https://raw.githubusercontent.com/ThijsKoot/openlineage-go/main/pkg/run/otelrun/TraceRunFacet.json"
+)
+
+// Attribute keys set on spans started for Runs.
+const (
+	RunIDKey        = attribute.Key("openlineage.run.id")
+	JobNameKey      = attribute.Key("openlineage.job.name")
+	JobNamespaceKey = attribute.Key("openlineage.job.namespace")
+)
+
+// TraceFacet is a run facet containing the OpenTelemetry span that was active when a Run was created.
+type TraceFacet struct {
+	facets.BaseFacet
+
+	TraceID string `json:"traceId"`
+	SpanID  string `json:"spanId"`
+}
+
+// NewTraceFacet creates a run facet containing a [TraceFacet] for sc.
+func NewTraceFacet(sc trace.SpanContext) *facets.CustomFacet[facets.RunFacets] {
+	return facets.NewCustomRunFacet(FacetName, TraceFacet{
+		BaseFacet: facets.NewBaseFacet(traceFacetSchemaURL),
+		TraceID:   sc.TraceID().String(),
+		SpanID:    sc.SpanID().String(),
+	})
+}
+
+// Option configures a [Hook].
+type Option func(*Hook)
+
+// WithTracer makes the Hook start a span for every Run using tracer.
+// Spans carry the run ID and job as attributes, and end when the Run is finished.
+func WithTracer(tracer trace.Tracer) Option {
+	return func(h *Hook) {
+		h.tracer = tracer
+	}
+}
+
+var _ run.Hook = (*Hook)(nil)
+
+// Hook is a [run.Hook] that bridges Runs and OpenTelemetry spans.
+type Hook struct {
+	tracer trace.Tracer
+
+	// spans started by this hook, by run ID
+	spans sync.Map
+}
+
+// NewHook creates a Hook.
+// By default, it only records the active span, see [WithTracer] to start spans for Runs.
+func NewHook(opts ...Option) *Hook {
+	h := &Hook{}
+	for _, o := range opts {
+		o(h)
+	}
+
+	return h
+}
+
+// OnNewRun implements run.Hook.
+func (h *Hook) OnNewRun(ctx context.Context, r run.Run) (context.Context, []facets.RunFacet) {
+	if h.tracer != nil {
+		var span trace.Span
+		ctx, span = h.tracer.Start(ctx, r.JobName(), trace.WithAttributes(
+			RunIDKey.String(r.RunID().String()),
+			JobNameKey.String(r.JobName()),
+			JobNamespaceKey.String(r.JobNamespace()),
+		))
+
+		h.spans.Store(r.RunID(), span)
+	}
+
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return ctx, nil
+	}
+
+	return ctx, []facets.RunFacet{NewTraceFacet(sc)}
+}
+
+// OnFinish implements run.Hook.
+func (h *Hook) OnFinish(r run.Run) {
+	s, ok := h.spans.LoadAndDelete(r.RunID())
+	if !ok {
+		return
+	}
+
+	span := s.(trace.Span)
+	if r.HasFailed() {
+		span.SetStatus(codes.Error, "run failed")
+	}
+
+	span.End()
+}
@@ -0,0 +1,167 @@
+package otelrun_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/oltest"
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+	"github.com/ThijsKoot/openlineage-go/pkg/run/otelrun"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// traceFacet returns the trace facet of the START event recorded for r.
+func traceFacet(t *testing.T, client *run.Client, rec *oltest.Recorder, r run.Run) (otelrun.TraceFacet, bool) {
+	t.Helper()
+
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	starts := oltest.Select(rec.Events(), oltest.ByRunID(r.RunID().String()), oltest.ByEventType(openlineage.EventTypeStart))
+	if len(starts) != 1 {
+		t.Fatalf("%d START events for %s, want 1", len(starts), r.JobName())
+	}
+
+	if starts[0].Run.Facets == nil {
+		return otelrun.TraceFacet{}, false
+	}
+
+	custom, ok := starts[0].Run.Facets.Custom[otelrun.FacetName]
+	if !ok {
+		return otelrun.TraceFacet{}, false
+	}
+
+	// decode the facet as it is sent, rather than the value set by the hook
+	data, err := json.Marshal(custom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var f otelrun.TraceFacet
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatal(err)
+	}
+
+	return f, true
+}
+
+func Test_Hook_ActiveSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	olc, rec := oltest.NewClient("test")
+	client := run.NewClient(olc, run.WithHooks(otelrun.NewHook()))
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "outer")
+	_, r := client.StartRun(ctx, "job")
+	r.Finish()
+	span.End()
+
+	f, ok := traceFacet(t, client, rec, r)
+	if !ok {
+		t.Fatalf("START event has no %s facet", otelrun.FacetName)
+	}
+
+	if f.SchemaURL == "" || f.Producer == "" {
+		t.Errorf("facet has no _schemaURL or _producer: %+v", f)
+	}
+
+	if f.TraceID != span.SpanContext().TraceID().String() {
+		t.Errorf("traceId = %s, want %s", f.TraceID, span.SpanContext().TraceID())
+	}
+
+	if f.SpanID != span.SpanContext().SpanID().String() {
+		t.Errorf("spanId = %s, want %s", f.SpanID, span.SpanContext().SpanID())
+	}
+
+	if n := len(exporter.GetSpans()); n != 1 {
+		t.Errorf("%d spans exported, want only the outer span", n)
+	}
+}
+
+func Test_Hook_NoActiveSpan(t *testing.T) {
+	olc, rec := oltest.NewClient("test")
+	client := run.NewClient(olc, run.WithHooks(otelrun.NewHook()))
+
+	_, r := client.StartRun(context.Background(), "job")
+
+	if _, ok := traceFacet(t, client, rec, r); ok {
+		t.Error("trace facet recorded without an active span")
+	}
+}
+
+func Test_Hook_WithTracer(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	hook := otelrun.NewHook(otelrun.WithTracer(tp.Tracer("test")))
+	olc, rec := oltest.NewClient("test")
+	client := run.NewClient(olc, run.WithHooks(hook))
+
+	ctx, parent := client.StartRun(context.Background(), "parent")
+	_, child := parent.StartChild(ctx, "child")
+
+	child.RecordError(errors.New("failed"))
+	child.Finish()
+	parent.Finish()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("%d spans exported, want 2", len(spans))
+	}
+
+	byName := map[string]tracetest.SpanStub{}
+	for _, s := range spans {
+		byName[s.Name] = s
+	}
+
+	parentSpan, childSpan := byName["parent"], byName["child"]
+
+	if childSpan.Parent.SpanID() != parentSpan.SpanContext.SpanID() {
+		t.Error("child span is not a child of the parent span")
+	}
+
+	if childSpan.Status.Code != codes.Error {
+		t.Errorf("child span status = %v, want %v", childSpan.Status.Code, codes.Error)
+	}
+
+	if parentSpan.Status.Code == codes.Error {
+		t.Error("parent span has error status")
+	}
+
+	attrs := map[string]string{}
+	for _, kv := range childSpan.Attributes {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	wantAttrs := map[string]string{
+		string(otelrun.RunIDKey):        child.RunID().String(),
+		string(otelrun.JobNameKey):      "child",
+		string(otelrun.JobNamespaceKey): "test",
+	}
+
+	for k, want := range wantAttrs {
+		if attrs[k] != want {
+			t.Errorf("attribute %s = %q, want %q", k, attrs[k], want)
+		}
+	}
+
+	f, ok := traceFacet(t, client, rec, child)
+	if !ok {
+		t.Fatalf("START event of child has no %s facet", otelrun.FacetName)
+	}
+
+	if f.SpanID != childSpan.SpanContext.SpanID().String() {
+		t.Errorf("spanId = %s, want span of child run %s", f.SpanID, childSpan.SpanContext.SpanID())
+	}
+
+	if f.TraceID != parentSpan.SpanContext.TraceID().String() {
+		t.Error("child run recorded a different trace than its parent")
+	}
+}
@@ -0,0 +1,186 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Keys under which run identity is propagated.
+// Carriers translate these into a format suitable for their medium.
+const (
+	keyParentRunID        = "parent-run-id"
+	keyParentJobName      = "parent-job-name"
+	keyParentJobNamespace = "parent-job-namespace"
+	keyRootRunID          = "root-parent-run-id"
+	keyRootJobName        = "root-parent-job-name"
+	keyRootJobNamespace   = "root-parent-job-namespace"
+)
+
+// Carrier stores propagated run identity, for example in HTTP headers or environment variables.
+type Carrier interface {
+	// Get returns the value associated with key, or an empty string if it is not present.
+	Get(key string) string
+
+	// Set stores value under key.
+	Set(key string, value string)
+}
+
+// Inject writes the identity of the Run in ctx, and that of its root, to carrier.
+// If ctx contains no Run, carrier is left untouched.
+func Inject(ctx context.Context, carrier Carrier) {
+	r := FromContext(ctx)
+	if _, isNoop := r.(*noopRun); isNoop {
+		return
+	}
+
+	carrier.Set(keyParentRunID, r.RunID().String())
+	carrier.Set(keyParentJobName, r.JobName())
+	carrier.Set(keyParentJobNamespace, r.JobNamespace())
+
+	root := r.Root()
+	carrier.Set(keyRootRunID, root.RunID().String())
+	carrier.Set(keyRootJobName, root.JobName())
+	carrier.Set(keyRootJobNamespace, root.JobNamespace())
+}
+
+// Extract reads a run identity from carrier and returns a copy of ctx with it set as the current Run.
+// The resulting Run only carries identity and performs no operations,
+// but Runs created from the returned context use it as their parent.
+// Its NewChild and StartChild methods use [openlineage.DefaultClient], prefer [Client.NewRun] and [Client.StartRun].
+// If carrier does not contain a valid run identity, ctx is returned unchanged.
+func Extract(ctx context.Context, carrier Carrier) context.Context {
+	parent := extractRemote(carrier)
+	if parent == nil {
+		return ctx
+	}
+
+	return ContextWithRun(ctx, parent)
+}
+
+func extractRemote(carrier Carrier) *remoteRun {
+	parent := remoteFromKeys(carrier, keyParentRunID, keyParentJobName, keyParentJobNamespace)
+	if parent == nil {
+		return nil
+	}
+
+	parent.root = remoteFromKeys(carrier, keyRootRunID, keyRootJobName, keyRootJobNamespace)
+
+	return parent
+}
+
+func remoteFromKeys(carrier Carrier, runIDKey, jobNameKey, jobNamespaceKey string) *remoteRun {
+	runID, err := uuid.Parse(carrier.Get(runIDKey))
+	if err != nil {
+		return nil
+	}
+
+	jobName := carrier.Get(jobNameKey)
+	jobNamespace := carrier.Get(jobNamespaceKey)
+	if jobName == "" || jobNamespace == "" {
+		return nil
+	}
+
+	return &remoteRun{
+		runID:        runID,
+		jobName:      jobName,
+		jobNamespace: jobNamespace,
+	}
+}
+
+// environmentParent is the run identity found in the environment of the current process, if any.
+// It is used as the parent of runs created without a Run in their context.
+var environmentParent = sync.OnceValue(func() Run {
+	env := EnvCarrier(os.Environ())
+	if parent := extractRemote(&env); parent != nil {
+		return parent
+	}
+
+	return nil
+})
+
+var _ Carrier = (HeaderCarrier)(nil)
+
+// HeaderCarrier adapts [http.Header] to a [Carrier].
+// Keys are stored as "Openlineage-Parent-Run-Id", etc.
+type HeaderCarrier http.Header
+
+// Get implements Carrier.
+func (c HeaderCarrier) Get(key string) string {
+	return http.Header(c).Get(headerKey(key))
+}
+
+// Set implements Carrier.
+func (c HeaderCarrier) Set(key string, value string) {
+	http.Header(c).Set(headerKey(key), value)
+}
+
+func headerKey(key string) string {
+	return http.CanonicalHeaderKey("openlineage-" + key)
+}
+
+var _ Carrier = (MetadataCarrier)(nil)
+
+// MetadataCarrier adapts message metadata, such as Kafka headers or gRPC metadata, to a [Carrier].
+// Keys are stored in lowercase as "openlineage-parent-run-id", etc.
+type MetadataCarrier map[string]string
+
+// Get implements Carrier.
+func (c MetadataCarrier) Get(key string) string {
+	return c[metadataKey(key)]
+}
+
+// Set implements Carrier.
+func (c MetadataCarrier) Set(key string, value string) {
+	c[metadataKey(key)] = value
+}
+
+func metadataKey(key string) string {
+	return "openlineage-" + key
+}
+
+var _ Carrier = (*EnvCarrier)(nil)
+
+// EnvCarrier adapts a list of environment variables in the form "KEY=value",
+// as returned by [os.Environ] and used by [os/exec.Cmd], to a [Carrier].
+// Keys are stored as OPENLINEAGE_PARENT_RUN_ID, etc.
+//
+// A process started with these variables in its environment will use the propagated
+// run as the parent for runs created without a Run in their context.
+type EnvCarrier []string
+
+// Get implements Carrier.
+func (c *EnvCarrier) Get(key string) string {
+	prefix := envKey(key) + "="
+
+	// later entries take precedence, as they do for os/exec
+	for i := len(*c) - 1; i >= 0; i-- {
+		if v, ok := strings.CutPrefix((*c)[i], prefix); ok {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// Set implements Carrier.
+func (c *EnvCarrier) Set(key string, value string) {
+	prefix := envKey(key) + "="
+
+	for i := len(*c) - 1; i >= 0; i-- {
+		if strings.HasPrefix((*c)[i], prefix) {
+			(*c)[i] = prefix + value
+			return
+		}
+	}
+
+	*c = append(*c, prefix+value)
+}
+
+func envKey(key string) string {
+	return "OPENLINEAGE_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
@@ -0,0 +1,203 @@
+package run_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/oltest"
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+)
+
+// newTestClient creates a Client in namespace "test" that records its events.
+func newTestClient(opts ...run.ClientOption) (*run.Client, *oltest.Recorder) {
+	olc, rec := oltest.NewClient("test")
+
+	return run.NewClient(olc, opts...), rec
+}
+
+func Test_Propagation(t *testing.T) {
+	carriers := []struct {
+		name    string
+		carrier func() run.Carrier
+		key     string
+	}{
+		{
+			name:    "header",
+			carrier: func() run.Carrier { return run.HeaderCarrier(http.Header{}) },
+			key:     "Openlineage-Parent-Run-Id",
+		},
+		{
+			name:    "metadata",
+			carrier: func() run.Carrier { return run.MetadataCarrier{} },
+			key:     "openlineage-parent-run-id",
+		},
+		{
+			name:    "env",
+			carrier: func() run.Carrier { return &run.EnvCarrier{"FOO=bar"} },
+			key:     "OPENLINEAGE_PARENT_RUN_ID",
+		},
+	}
+
+	for _, tt := range carriers {
+		t.Run(tt.name, func(t *testing.T) {
+			client, events := newTestClient()
+
+			ctx, root := client.NewRun(context.Background(), "root")
+			ctx, parent := root.NewChild(ctx, "parent")
+
+			carrier := tt.carrier()
+			run.Inject(ctx, carrier)
+
+			switch c := carrier.(type) {
+			case run.HeaderCarrier:
+				if got := http.Header(c).Get(tt.key); got != parent.RunID().String() {
+					t.Errorf("header %s = %q, want %q", tt.key, got, parent.RunID())
+				}
+			case run.MetadataCarrier:
+				if got := c[tt.key]; got != parent.RunID().String() {
+					t.Errorf("metadata %s = %q, want %q", tt.key, got, parent.RunID())
+				}
+			case *run.EnvCarrier:
+				want := tt.key + "=" + parent.RunID().String()
+				found := false
+				for _, kv := range *c {
+					found = found || kv == want
+				}
+				if !found {
+					t.Errorf("environment %v does not contain %q", *c, want)
+				}
+			}
+
+			remoteCtx := run.Extract(context.Background(), carrier)
+			remote := run.FromContext(remoteCtx)
+
+			if remote.RunID() != parent.RunID() {
+				t.Errorf("extracted run ID = %s, want %s", remote.RunID(), parent.RunID())
+			}
+
+			if remote.JobName() != "parent" {
+				t.Errorf("extracted job name = %q, want %q", remote.JobName(), "parent")
+			}
+
+			_, child := client.StartRun(remoteCtx, "child")
+			assertParent(t, events.Events(), child, parent)
+		})
+	}
+}
+
+func Test_Extract_Invalid(t *testing.T) {
+	cases := []struct {
+		name     string
+		metadata run.MetadataCarrier
+	}{
+		{
+			name:     "empty",
+			metadata: run.MetadataCarrier{},
+		},
+		{
+			name: "invalid-run-id",
+			metadata: run.MetadataCarrier{
+				"openlineage-parent-run-id":        "not-a-uuid",
+				"openlineage-parent-job-name":      "job",
+				"openlineage-parent-job-namespace": "ns",
+			},
+		},
+		{
+			name: "missing-job",
+			metadata: run.MetadataCarrier{
+				"openlineage-parent-run-id": "0190e8b5-5f4e-7a4c-a1a4-16d4ba5c1b8c",
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if got := run.Extract(ctx, tt.metadata); got != ctx {
+				t.Error("Extract modified context for invalid carrier")
+			}
+		})
+	}
+}
+
+// assertParent checks that the START event of child was recorded with parent in its parent facet.
+func assertParent(t *testing.T, events []openlineage.Event, child, parent run.Run) {
+	t.Helper()
+
+	starts := oltest.Select(events, oltest.ByRunID(child.RunID().String()), oltest.ByEventType(openlineage.EventTypeStart))
+	if len(starts) != 1 {
+		t.Fatalf("%d START events for %s, want 1", len(starts), child.JobName())
+	}
+
+	facets := starts[0].Run.Facets
+	if facets == nil || facets.Parent == nil {
+		t.Fatalf("START event of %s has no parent facet", child.JobName())
+	}
+
+	if got := facets.Parent.Run.RunID; got != parent.RunID().String() {
+		t.Errorf("parent facet run ID = %s, want %s", got, parent.RunID())
+	}
+
+	if got := facets.Parent.Job.Name; got != parent.JobName() {
+		t.Errorf("parent facet job = %s, want %s", got, parent.JobName())
+	}
+}
+
+func Test_NewRun_EnvironmentParent(t *testing.T) {
+	// the parent is read from the environment once per process, so the run is started in a new one
+	if os.Getenv("RUN_TEST_ENVIRONMENT_PARENT") != "" {
+		client, events := newTestClient()
+		client.StartRun(context.Background(), "child")
+
+		fmt.Println(events.Events()[0].Run.Facets.Parent.Run.RunID)
+
+		return
+	}
+
+	client, _ := newTestClient()
+	ctx, parent := client.NewRun(context.Background(), "parent")
+
+	env := run.EnvCarrier(append(os.Environ(), "RUN_TEST_ENVIRONMENT_PARENT=1"))
+	run.Inject(ctx, &env)
+
+	cmd := exec.Command(os.Args[0], "-test.run=^Test_NewRun_EnvironmentParent$")
+	cmd.Env = env
+
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("run child process: %s", err)
+	}
+
+	if got, _, _ := strings.Cut(string(out), "\n"); got != parent.RunID().String() {
+		t.Errorf("parent run ID in child process = %q, want %s", got, parent.RunID())
+	}
+}
+
+func Test_RemoteRun_StartChild(t *testing.T) {
+	olc, events := oltest.NewClient("test")
+
+	defaultClient := openlineage.DefaultClient
+	openlineage.DefaultClient = olc
+	t.Cleanup(func() { openlineage.DefaultClient = defaultClient })
+
+	client, _ := newTestClient()
+	ctx, parent := client.NewRun(context.Background(), "parent")
+
+	carrier := run.MetadataCarrier{}
+	run.Inject(ctx, carrier)
+
+	remoteCtx := run.Extract(context.Background(), carrier)
+	ctx, child := run.FromContext(remoteCtx).StartChild(remoteCtx, "child")
+
+	if run.FromContext(ctx) != child {
+		t.Error("child is not stored in the returned context")
+	}
+
+	assertParent(t, events.Events(), child, parent)
+}
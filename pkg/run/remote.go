@@ -0,0 +1,96 @@
+package run
+
+import (
+	"context"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
+	"github.com/google/uuid"
+)
+
+var _ Run = (*remoteRun)(nil)
+
+// remoteRun is a Run that lives in another process.
+// It only carries the identity of that run, so it can be used as a parent, and otherwise performs no operations.
+// Its children are created with [openlineage.DefaultClient].
+type remoteRun struct {
+	runID        uuid.UUID
+	jobName      string
+	jobNamespace string
+
+	// root is the root of the remote run's tree, if it was propagated
+	root *remoteRun
+}
+
+// RecordRunFacets implements Run.
+func (r *remoteRun) RecordRunFacets(...facets.RunFacet) {}
+
+// RecordJobFacets implements Run.
+func (r *remoteRun) RecordJobFacets(...facets.JobFacet) {}
+
+// RecordInputs implements Run.
+func (r *remoteRun) RecordInputs(...openlineage.InputElement) {}
+
+// RecordOutputs implements Run.
+func (r *remoteRun) RecordOutputs(...openlineage.OutputElement) {}
+
+// NewChild implements Run.
+// A remote run has no client of its own, so the child is created with [New], using [openlineage.DefaultClient].
+// Use [Client.NewRun] with ctx to create the child with another client.
+func (r *remoteRun) NewChild(ctx context.Context, jobName string, opts ...RunOption) (context.Context, Run) {
+	return New(ContextWithRun(ctx, r), jobName, opts...)
+}
+
+// StartChild implements Run.
+// A remote run has no client of its own, so the child is started with [Start], using [openlineage.DefaultClient].
+// Use [Client.StartRun] with ctx to start the child with another client.
+func (r *remoteRun) StartChild(ctx context.Context, jobName string, opts ...RunOption) (context.Context, Run) {
+	return Start(ContextWithRun(ctx, r), jobName, opts...)
+}
+
+// HasFailed implements Run.
+func (r *remoteRun) HasFailed() bool {
+	return false
+}
+
+// Finish implements Run.
+func (r *remoteRun) Finish() {}
+
+// RecordError implements Run.
+func (r *remoteRun) RecordError(error) {}
+
+// NewEvent implements Run.
+func (r *remoteRun) NewEvent(openlineage.EventType) *openlineage.RunEvent {
+	return &openlineage.RunEvent{}
+}
+
+// JobName implements Run.
+func (r *remoteRun) JobName() string {
+	return r.jobName
+}
+
+// JobNamespace implements Run.
+func (r *remoteRun) JobNamespace() string {
+	return r.jobNamespace
+}
+
+// Parent implements Run.
+// The parents of a remote run are unknown.
+func (r *remoteRun) Parent() Run {
+	return nil
+}
+
+// Root implements Run.
+// If the root was not propagated, the remote run itself is considered the root.
+func (r *remoteRun) Root() Run {
+	if r.root == nil {
+		return r
+	}
+
+	return r.root
+}
+
+// RunID implements Run.
+func (r *remoteRun) RunID() uuid.UUID {
+	return r.runID
+}
@@ -0,0 +1,141 @@
+package run
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
+)
+
+const (
+	// AttemptFacetName is the name of the run facet containing the attempt number of a Run started by [WithRetry].
+	AttemptFacetName = "attempt"
+
+	attemptFacetSchemaURL = "https://raw.githubusercontent.com/ThijsKoot/openlineage-go/main/pkg/run/AttemptRunFacet.json"
+)
+
+// AttemptFacet is a run facet containing the attempt number of a Run.
+type AttemptFacet struct {
+	facets.BaseFacet
+
+	Number int `json:"number"`
+}
+
+// NewAttemptFacet creates a run facet containing an [AttemptFacet].
+func NewAttemptFacet(number int) *facets.CustomFacet[facets.RunFacets] {
+	return facets.NewCustomRunFacet(AttemptFacetName, AttemptFacet{
+		BaseFacet: facets.NewBaseFacet(attemptFacetSchemaURL),
+		Number:    number,
+	})
+}
+
+// RetryPolicy determines whether and when failed attempts are retried.
+type RetryPolicy interface {
+	// NextBackoff is called after attempt failed with err.
+	// It returns how long to wait before the next attempt, or false if no further attempts should be made.
+	NextBackoff(attempt int, err error) (time.Duration, bool)
+}
+
+var _ RetryPolicy = ExponentialBackoff{}
+
+// ExponentialBackoff is a RetryPolicy with exponentially increasing backoff durations.
+type ExponentialBackoff struct {
+	// MaxAttempts is the total number of attempts, including the first. Values below 1 are treated as 1.
+	MaxAttempts int
+
+	// Initial is the backoff after the first attempt.
+	Initial time.Duration
+
+	// Max caps the backoff duration if it is positive.
+	Max time.Duration
+
+	// Multiplier is the factor by which the backoff increases after each attempt (default: 2).
+	Multiplier float64
+}
+
+// NextBackoff implements RetryPolicy.
+func (b ExponentialBackoff) NextBackoff(attempt int, _ error) (time.Duration, bool) {
+	if attempt >= b.MaxAttempts {
+		return 0, false
+	}
+
+	multiplier := b.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	backoff := time.Duration(float64(b.Initial) * math.Pow(multiplier, float64(attempt-1)))
+	if b.Max > 0 && backoff > b.Max {
+		backoff = b.Max
+	}
+
+	return backoff, true
+}
+
+// WithRetry calls fn until it succeeds or policy stops retrying.
+//
+// A logical Run for job is started with [Client.StartRun], so the Run in ctx is its parent if there is one,
+// and every attempt is a child Run of the logical Run with an [AttemptFacet].
+// Attempts finish with COMPLETE or FAIL depending on the error returned by fn,
+// and the logical Run finishes with the outcome of the final attempt.
+// If ctx is done while waiting to retry, the logical Run is aborted instead.
+//
+// WithRetry returns the error of the final attempt, or the context's error if ctx is done while waiting to retry.
+func (c *Client) WithRetry(ctx context.Context, job string, policy RetryPolicy, fn func(ctx context.Context, attempt int) error) error {
+	ctx, logical := c.StartRun(ctx, job)
+
+	err := retry(ctx, job, logical, policy, fn)
+	switch {
+	case err == nil:
+		logical.Finish()
+	case ctx.Err() != nil:
+		logical.(*run).abort()
+	default:
+		logical.RecordError(err)
+		logical.Finish()
+	}
+
+	return err
+}
+
+// WithRetry calls [Client.WithRetry] using the client of the Run in ctx.
+// If ctx contains no Run created in this process, [openlineage.DefaultClient] is used.
+func WithRetry(ctx context.Context, job string, policy RetryPolicy, fn func(ctx context.Context, attempt int) error) error {
+	if r, ok := FromContext(ctx).(*run); ok {
+		return r.client.WithRetry(ctx, job, policy, fn)
+	}
+
+	return NewClient(openlineage.DefaultClient).WithRetry(ctx, job, policy, fn)
+}
+
+func retry(ctx context.Context, job string, logical Run, policy RetryPolicy, fn func(ctx context.Context, attempt int) error) error {
+	for attempt := 1; ; attempt++ {
+		attemptCtx, attemptRun := logical.StartChild(ctx, job, WithRunFacets(NewAttemptFacet(attempt)))
+
+		err := fn(attemptCtx, attempt)
+		if err != nil {
+			attemptRun.RecordError(err)
+		}
+
+		attemptRun.Finish()
+
+		if err == nil {
+			return nil
+		}
+
+		backoff, ok := policy.NextBackoff(attempt, err)
+		if !ok {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package run_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/oltest"
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+)
+
+func Test_WithRetry(t *testing.T) {
+	cases := []struct {
+		name         string
+		failures     int
+		maxAttempts  int
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "first-attempt",
+			maxAttempts:  3,
+			wantAttempts: 1,
+		},
+		{
+			name:         "succeeds-after-retry",
+			failures:     2,
+			maxAttempts:  3,
+			wantAttempts: 3,
+		},
+		{
+			name:         "exhausted",
+			failures:     5,
+			maxAttempts:  3,
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient()
+			ctx, parent := client.NewRun(context.Background(), "parent")
+
+			policy := run.ExponentialBackoff{
+				MaxAttempts: tt.maxAttempts,
+				Initial:     time.Millisecond,
+			}
+
+			var attempts []run.Run
+			err := run.WithRetry(ctx, "flaky", policy, func(ctx context.Context, attempt int) error {
+				attempts = append(attempts, run.FromContext(ctx))
+
+				if attempt <= tt.failures {
+					return errors.New("failed")
+				}
+
+				return nil
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithRetry() = %v, want error: %v", err, tt.wantErr)
+			}
+
+			if len(attempts) != tt.wantAttempts {
+				t.Fatalf("%d attempts, want %d", len(attempts), tt.wantAttempts)
+			}
+
+			logical := attempts[0].Parent()
+			if logical.JobName() != "flaky" || logical.Parent().RunID() != parent.RunID() {
+				t.Error("attempts are not children of a logical run for the job")
+			}
+
+			if logical.HasFailed() != tt.wantErr {
+				t.Errorf("logical run HasFailed() = %v, want %v", logical.HasFailed(), tt.wantErr)
+			}
+
+			for i, a := range attempts {
+				if a.Parent().RunID() != logical.RunID() {
+					t.Errorf("attempt %d is not a child of the logical run", i+1)
+				}
+
+				wantFailed := i+1 <= tt.failures
+				if a.HasFailed() != wantFailed {
+					t.Errorf("attempt %d HasFailed() = %v, want %v", i+1, a.HasFailed(), wantFailed)
+				}
+
+				event := a.NewEvent(openlineage.EventTypeStart)
+				facet, ok := event.Run.Facets.Custom[run.AttemptFacetName].(run.AttemptFacet)
+				if !ok || facet.Number != i+1 {
+					t.Errorf("attempt %d has attempt facet %#v", i+1, event.Run.Facets.Custom[run.AttemptFacetName])
+				}
+			}
+		})
+	}
+}
+
+func Test_WithRetry_Canceled(t *testing.T) {
+	client, rec := newTestClient()
+	ctx, _ := client.NewRun(context.Background(), "parent")
+	ctx, cancel := context.WithCancel(ctx)
+
+	policy := run.ExponentialBackoff{
+		MaxAttempts: 3,
+		Initial:     time.Hour,
+	}
+
+	var logical run.Run
+	err := run.WithRetry(ctx, "flaky", policy, func(ctx context.Context, attempt int) error {
+		logical = run.FromContext(ctx).Parent()
+		cancel()
+		return errors.New("failed")
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("WithRetry() = %v, want %v", err, context.Canceled)
+	}
+
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), time.Second)
+	defer flushCancel()
+
+	if err := client.Flush(flushCtx); err != nil {
+		t.Fatal(err)
+	}
+
+	var types []openlineage.EventType
+	for _, e := range oltest.Select(rec.Events(), oltest.ByRunID(logical.RunID().String())) {
+		types = append(types, *e.EventType)
+	}
+
+	want := []openlineage.EventType{openlineage.EventTypeStart, openlineage.EventTypeAbort}
+	if !slices.Equal(types, want) {
+		t.Errorf("logical run emitted %v, want %v", types, want)
+	}
+}
+
+func Test_ExponentialBackoff(t *testing.T) {
+	policy := run.ExponentialBackoff{
+		MaxAttempts: 5,
+		Initial:     time.Second,
+		Max:         5 * time.Second,
+	}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		got, ok := policy.NextBackoff(i+1, nil)
+		if !ok || got != w {
+			t.Errorf("NextBackoff(%d) = %s, %v, want %s, true", i+1, got, ok, w)
+		}
+	}
+
+	if _, ok := policy.NextBackoff(5, nil); ok {
+		t.Error("NextBackoff allowed more than MaxAttempts attempts")
+	}
+}
+
+func Test_Client_WithRetry(t *testing.T) {
+	remoteParent := run.MetadataCarrier{
+		"openlineage-parent-run-id":        "0190e8b5-5f4e-7a4c-a1a4-16d4ba5c1b8c",
+		"openlineage-parent-job-name":      "remote",
+		"openlineage-parent-job-namespace": "other",
+	}
+
+	cases := []struct {
+		name       string
+		ctx        context.Context
+		wantParent string
+	}{
+		{
+			name: "no-parent",
+			ctx:  context.Background(),
+		},
+		{
+			name:       "remote-parent",
+			ctx:        run.Extract(context.Background(), remoteParent),
+			wantParent: "0190e8b5-5f4e-7a4c-a1a4-16d4ba5c1b8c",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rec := newTestClient()
+
+			policy := run.ExponentialBackoff{MaxAttempts: 3, Initial: time.Millisecond}
+			err := client.WithRetry(tt.ctx, "flaky", policy, func(ctx context.Context, attempt int) error {
+				if attempt == 1 {
+					return errors.New("failed")
+				}
+
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("WithRetry() = %v", err)
+			}
+
+			if err := client.Flush(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			events := rec.Events()
+
+			starts := oltest.Select(events, oltest.ByEventType(openlineage.EventTypeStart))
+			if len(starts) != 3 {
+				t.Fatalf("%d START events, want one for the logical run and one per attempt", len(starts))
+			}
+
+			// the logical run is started first
+			logical := starts[0]
+			if got := parentRunID(logical); got != tt.wantParent {
+				t.Errorf("logical run parent = %q, want %q", got, tt.wantParent)
+			}
+
+			for i, attempt := range starts[1:] {
+				if got := parentRunID(attempt); got != logical.Run.RunID {
+					t.Errorf("attempt %d parent = %q, want logical run %s", i+1, got, logical.Run.RunID)
+				}
+
+				if _, ok := attempt.Run.Facets.Custom[run.AttemptFacetName]; !ok {
+					t.Errorf("attempt %d has no attempt facet", i+1)
+				}
+			}
+
+			wantOutcomes := map[string]openlineage.EventType{
+				logical.Run.RunID:   openlineage.EventTypeComplete,
+				starts[1].Run.RunID: openlineage.EventTypeFail,
+				starts[2].Run.RunID: openlineage.EventTypeComplete,
+			}
+
+			for runID, want := range wantOutcomes {
+				if n := len(oltest.Select(events, oltest.ByRunID(runID), oltest.ByEventType(want))); n != 1 {
+					t.Errorf("run %s has %d %s events, want 1", runID, n, want)
+				}
+			}
+		})
+	}
+}
+
+func parentRunID(e openlineage.Event) string {
+	if e.Run.Facets == nil || e.Run.Facets.Parent == nil {
+		return ""
+	}
+
+	return e.Run.Facets.Parent.Run.RunID
+}
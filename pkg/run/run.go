@@ -3,6 +3,7 @@ package run
 import (
 	"context"
 	"runtime"
+	"sync"
 
 	"github.com/ThijsKoot/openlineage-go"
 	"github.com/ThijsKoot/openlineage-go/pkg/facets"
@@ -16,6 +17,9 @@ type Run interface {
 	// Parent returns the parent of this run, if any.
 	Parent() Run
 
+	// Root returns the top-most ancestor of this Run, or the Run itself if it has no parent.
+	Root() Run
+
 	// RunID returns the ID for this Run.
 	RunID() uuid.UUID
 
@@ -26,16 +30,16 @@ type Run interface {
 	JobNamespace() string
 
 	// NewChild creates a new Run with the current Run set as its parent
-	NewChild(ctx context.Context, jobName string) (context.Context, Run)
+	NewChild(ctx context.Context, jobName string, opts ...RunOption) (context.Context, Run)
 
 	// StartChild calls NewChild and emits a START event
-	StartChild(ctx context.Context, jobName string) (context.Context, Run)
+	StartChild(ctx context.Context, jobName string, opts ...RunOption) (context.Context, Run)
 
 	// NewEvent creates a new Event of the provided EventType
 	NewEvent(openlineage.EventType) *openlineage.RunEvent
 
 	// Finish will emit a COMPLETE event if no error has occurred.
-	// Otherwise, it will emit a FAIL event.
+	// Otherwise, it will emit a FAIL event with the ErrorMessage facet of the last recorded error.
 	Finish()
 
 	// Returns true if RecordError was called for this Run.
@@ -60,12 +64,20 @@ type Run interface {
 
 type run struct {
 	parent       Run
+	root         Run
 	runID        uuid.UUID
 	jobName      string
 	jobNamespace string
 
+	// runFacets are included in every event of this run
+	runFacets []facets.RunFacet
+
+	// mu guards hasFailed and lastError, which are recorded from any goroutine using the run
+	mu        sync.Mutex
 	hasFailed bool
-	client    *Client
+	lastError *facets.ErrorMessage
+
+	client *Client
 }
 
 // RecordFacets implements Run.
@@ -119,8 +131,16 @@ func (r *run) Parent() Run {
 	return r.parent
 }
 
+func (r *run) Root() Run {
+	if r.root == nil {
+		return r
+	}
+
+	return r.root
+}
+
 func (r *run) NewEvent(eventType openlineage.EventType) *openlineage.RunEvent {
-	run := openlineage.NewNamespacedRunEvent(
+	run := r.client.olc.NewNamespacedRunEvent(
 		eventType,
 		r.runID,
 		r.jobName,
@@ -128,6 +148,7 @@ func (r *run) NewEvent(eventType openlineage.EventType) *openlineage.RunEvent {
 	)
 
 	if r.Parent() != nil {
+		root := r.Root()
 		parent := facets.NewParent(
 			facets.Job{
 				Name:      r.parent.JobName(),
@@ -136,34 +157,51 @@ func (r *run) NewEvent(eventType openlineage.EventType) *openlineage.RunEvent {
 			facets.Run{
 				RunID: r.parent.RunID().String(),
 			},
-		)
+		).WithRoot(facets.Root{
+			Job: facets.Job{
+				Name:      root.JobName(),
+				Namespace: root.JobNamespace(),
+			},
+			Run: facets.Run{
+				RunID: root.RunID().String(),
+			},
+		})
 
 		run = run.WithRunFacets(parent)
 	}
 
-	return run
+	return run.WithRunFacets(r.runFacets...)
 }
 
 // NewChild calls [Client.NewRun]. [ctx] is expected to have a Run associated with it already.
-func (r *run) NewChild(ctx context.Context, jobName string) (context.Context, Run) {
-	return r.client.NewRun(ctx, jobName)
+func (r *run) NewChild(ctx context.Context, jobName string, opts ...RunOption) (context.Context, Run) {
+	return r.client.NewRun(ctx, jobName, opts...)
 }
 
 // StartChild calls [Client.StartRun]. [ctx] is expected to have a Run associated with it already.
-func (r *run) StartChild(ctx context.Context, jobName string) (context.Context, Run) {
-	return r.client.StartRun(ctx, jobName)
+func (r *run) StartChild(ctx context.Context, jobName string, opts ...RunOption) (context.Context, Run) {
+	return r.client.StartRun(ctx, jobName, opts...)
 }
 
-// Emit uses its openlineage.Client to emit an event
+// Emit uses its openlineage.Client to emit an event asynchronously, see [Client.Flush].
+// The event is emitted even if ctx is canceled before that happens.
+// Events waiting to be emitted are reported as the queue depth of the client's metrics.
 func (r *run) Emit(ctx context.Context, event openlineage.Emittable) {
+	ctx = context.WithoutCancel(ctx)
+
+	m := r.client.olc.Metrics()
+	m.QueueDepth(ctx, 1)
+	r.client.pending.add(1)
+
 	go func() {
+		defer r.client.pending.add(-1)
+		defer m.QueueDepth(ctx, -1)
+
 		_ = r.client.Emit(ctx, event)
 	}()
 }
 
 func (r *run) RecordError(err error) {
-	r.hasFailed = true
-
 	errorMessage := err.Error()
 
 	stacktrace := stack.Caller(1).String()
@@ -173,6 +211,11 @@ func (r *run) RecordError(err error) {
 		NewErrorMessage(errorMessage, language).
 		WithStackTrace(stacktrace)
 
+	r.mu.Lock()
+	r.hasFailed = true
+	r.lastError = errorFacet
+	r.mu.Unlock()
+
 	errorEvent := r.NewEvent(openlineage.EventTypeOther).
 		WithRunFacets(errorFacet)
 
@@ -180,14 +223,42 @@ func (r *run) RecordError(err error) {
 }
 
 func (r *run) Finish() {
+	r.finish(false)
+}
+
+// abort finishes the run with an ABORT event, for runs that were stopped before they could complete or fail.
+func (r *run) abort() {
+	r.finish(true)
+}
+
+func (r *run) finish(aborted bool) {
+	r.mu.Lock()
+	hasFailed, lastError := r.hasFailed, r.lastError
+	r.mu.Unlock()
+
 	eventType := openlineage.EventTypeComplete
-	if r.hasFailed {
+	switch {
+	case aborted:
+		eventType = openlineage.EventTypeAbort
+	case hasFailed:
 		eventType = openlineage.EventTypeFail
 	}
 
-	r.Emit(context.Background(), r.NewEvent(eventType))
+	event := r.NewEvent(eventType)
+	if eventType == openlineage.EventTypeFail && lastError != nil {
+		event = event.WithRunFacets(lastError)
+	}
+
+	for _, h := range r.client.hooks {
+		h.OnFinish(r)
+	}
+
+	r.Emit(context.Background(), event)
 }
 
 func (r *run) HasFailed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	return r.hasFailed
 }
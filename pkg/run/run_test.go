@@ -0,0 +1,161 @@
+package run_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/metrics"
+	"github.com/ThijsKoot/openlineage-go/pkg/oltest"
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+)
+
+func Test_Run_Root(t *testing.T) {
+	client, _ := newTestClient()
+
+	ctx, root := client.NewRun(context.Background(), "root")
+	ctx, middle := root.NewChild(ctx, "middle")
+	_, leaf := middle.NewChild(ctx, "leaf")
+
+	if root.Root() != root {
+		t.Error("root run is not its own root")
+	}
+
+	for _, r := range []run.Run{middle, leaf} {
+		if r.Root().RunID() != root.RunID() {
+			t.Errorf("%s: Root() = %s, want %s", r.JobName(), r.Root().RunID(), root.RunID())
+		}
+
+		parent := r.NewEvent(openlineage.EventTypeStart).Run.Facets.Parent
+		if parent.Root == nil {
+			t.Fatalf("%s: parent facet has no root", r.JobName())
+		}
+
+		if parent.Root.Run.RunID != root.RunID().String() || parent.Root.Job.Name != "root" {
+			t.Errorf("%s: parent.root = %+v, want run %s of job root", r.JobName(), *parent.Root, root.RunID())
+		}
+	}
+
+	if event := root.NewEvent(openlineage.EventTypeStart); event.Run.Facets != nil && event.Run.Facets.Parent != nil {
+		t.Error("root run has a parent facet")
+	}
+}
+
+func Test_Run_Root_Propagated(t *testing.T) {
+	client, _ := newTestClient()
+
+	ctx, root := client.NewRun(context.Background(), "root")
+	ctx, middle := root.NewChild(ctx, "middle")
+
+	carrier := run.MetadataCarrier{}
+	run.Inject(ctx, carrier)
+
+	_, remoteChild := client.NewRun(run.Extract(context.Background(), carrier), "remote-child")
+
+	if remoteChild.Parent().RunID() != middle.RunID() {
+		t.Errorf("Parent() = %s, want %s", remoteChild.Parent().RunID(), middle.RunID())
+	}
+
+	if remoteChild.Root().RunID() != root.RunID() {
+		t.Errorf("Root() = %s, want %s", remoteChild.Root().RunID(), root.RunID())
+	}
+}
+
+// queueRecorder tracks the queue depth reported to it.
+type queueRecorder struct {
+	metrics.Noop
+
+	depth, max atomic.Int64
+}
+
+func (q *queueRecorder) QueueDepth(_ context.Context, delta int64) {
+	depth := q.depth.Add(delta)
+	for {
+		m := q.max.Load()
+		if depth <= m || q.max.CompareAndSwap(m, depth) {
+			return
+		}
+	}
+}
+
+func Test_Run_QueueDepth(t *testing.T) {
+	rec := &queueRecorder{}
+	olc, events := oltest.NewClient("test", openlineage.WithMetrics(rec))
+	client := run.NewClient(olc)
+
+	_, r := client.StartRun(context.Background(), "job")
+	r.Finish()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := client.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(events.Events()); n != 2 {
+		t.Errorf("%d events emitted, want 2", n)
+	}
+
+	if rec.max.Load() < 1 {
+		t.Error("queued events were not reported")
+	}
+
+	if depth := rec.depth.Load(); depth != 0 {
+		t.Errorf("queue depth is %d after flushing", depth)
+	}
+}
+
+func Test_Run_SetNamespace(t *testing.T) {
+	events := oltest.NewRecorder()
+	olc := openlineage.NewClientWithTransport(openlineage.ClientConfig{
+		Namespace: "test",
+		Rules:     []openlineage.Rule{{Job: "nightly", SetNamespace: "reports"}},
+	}, events)
+	client := run.NewClient(olc)
+
+	ctx, root := client.StartRun(context.Background(), "nightly")
+	ctx, middle := root.StartChild(ctx, "load")
+	_, leaf := middle.StartChild(ctx, "step")
+
+	for _, r := range []run.Run{leaf, middle, root} {
+		r.Finish()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := client.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(oltest.Select(events.Events(), oltest.ByJob("reports", "nightly"))); n != 2 {
+		t.Errorf("%d events of nightly in namespace reports, want 2", n)
+	}
+
+	for _, e := range events.Events() {
+		if e.Job.Name == "nightly" {
+			continue
+		}
+
+		if e.Job.Namespace != "test" {
+			t.Errorf("%s: job namespace = %s, want test", e.Job.Name, e.Job.Namespace)
+		}
+
+		parent := e.Run.Facets.Parent
+		wantParent := "test"
+		if e.Job.Name == "load" {
+			wantParent = "reports"
+		}
+
+		if parent.Job.Namespace != wantParent {
+			t.Errorf("%s: parent job namespace = %s, want %s", e.Job.Name, parent.Job.Namespace, wantParent)
+		}
+
+		if parent.Root == nil || parent.Root.Job.Namespace != "reports" {
+			t.Errorf("%s: parent.root = %+v, want job nightly in namespace reports", e.Job.Name, parent.Root)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package run
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
+	"github.com/google/uuid"
+)
+
+// RunIDStrategy generates the ID of a new Run.
+// key is the key supplied with [WithKey] or [WithSchedule], and is empty if none was supplied.
+// If a strategy returns [uuid.Nil], the [openlineage.IDGenerator] of the Client's [openlineage.Client] is used instead.
+type RunIDStrategy func(jobNamespace, jobName, key string) uuid.UUID
+
+// RandomRunID returns a strategy that gives every Run a new ID from the Client's [openlineage.IDGenerator],
+// which generates random UUIDv7s unless the [openlineage.Client] was created with another one.
+func RandomRunID() RunIDStrategy {
+	return func(string, string, string) uuid.UUID {
+		return uuid.Nil
+	}
+}
+
+// DeterministicRunID returns a strategy that derives UUIDv5 run IDs from namespace,
+// the job's namespace and name, and the run's key.
+// Runs with the same job and key get the same ID, which links retries and restarts together.
+// Runs without a key get an ID from the Client's [openlineage.IDGenerator].
+func DeterministicRunID(namespace uuid.UUID) RunIDStrategy {
+	return func(jobNamespace, jobName, key string) uuid.UUID {
+		if key == "" {
+			return uuid.Nil
+		}
+
+		name := strings.Join([]string{jobNamespace, jobName, key}, "\x00")
+
+		return uuid.NewSHA1(namespace, []byte(name))
+	}
+}
+
+// WithRunIDStrategy sets the strategy used to generate IDs for new Runs.
+func WithRunIDStrategy(strategy RunIDStrategy) ClientOption {
+	return func(c *Client) {
+		c.runIDStrategy = strategy
+	}
+}
+
+type runConfig struct {
+	runID     *uuid.UUID
+	key       string
+	runFacets []facets.RunFacet
+}
+
+// RunOption configures a single Run.
+type RunOption func(*runConfig)
+
+// WithRunID sets the ID of the Run, bypassing the Client's [RunIDStrategy].
+// [uuid.Nil] is not a valid run ID: WithRunID(uuid.Nil) has no effect, and the ID is generated as without the option.
+func WithRunID(runID uuid.UUID) RunOption {
+	return func(c *runConfig) {
+		if runID != uuid.Nil {
+			c.runID = &runID
+		}
+	}
+}
+
+// WithKey sets the key passed to the Client's [RunIDStrategy].
+func WithKey(key string) RunOption {
+	return func(c *runConfig) {
+		c.key = key
+	}
+}
+
+// WithRunFacets adds facets to every event of the Run.
+func WithRunFacets(runFacets ...facets.RunFacet) RunOption {
+	return func(c *runConfig) {
+		c.runFacets = append(c.runFacets, runFacets...)
+	}
+}
+
+// WithNominalTime adds a [facets.NominalTime] facet to every event of the Run.
+// If end is the zero time, the nominal end time is omitted.
+func WithNominalTime(start, end time.Time) RunOption {
+	return func(c *runConfig) {
+		nominalTime := facets.NewNominalTime(start.Format(time.RFC3339))
+		if !end.IsZero() {
+			nominalTime = nominalTime.WithNominalEndTime(end.Format(time.RFC3339))
+		}
+
+		c.runFacets = append(c.runFacets, nominalTime)
+	}
+}
+
+// WithSchedule configures a Run started by a scheduler for the logical window from start to end.
+// The Run gets a key derived from start and attempt (see [WithKey]) and the window as its nominal time (see [WithNominalTime]).
+// Combined with [DeterministicRunID], restarts of the same attempt share their run ID.
+func WithSchedule(start, end time.Time, attempt int) RunOption {
+	return func(c *runConfig) {
+		WithKey(fmt.Sprintf("%s/%d", start.UTC().Format(time.RFC3339Nano), attempt))(c)
+		WithNominalTime(start, end)(c)
+	}
+}
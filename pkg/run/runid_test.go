@@ -0,0 +1,102 @@
+package run_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/oltest"
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+	"github.com/google/uuid"
+)
+
+func Test_DeterministicRunID(t *testing.T) {
+	namespace := uuid.MustParse("6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+	client, _ := newTestClient(run.WithRunIDStrategy(run.DeterministicRunID(namespace)))
+
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	newRunID := func(job string, attempt int) uuid.UUID {
+		_, r := client.NewRun(context.Background(), job, run.WithSchedule(start, end, attempt))
+		return r.RunID()
+	}
+
+	first := newRunID("load", 1)
+	if first.Version() != 5 {
+		t.Errorf("run ID version = %d, want 5", first.Version())
+	}
+
+	if restart := newRunID("load", 1); restart != first {
+		t.Errorf("restart got run ID %s, want %s", restart, first)
+	}
+
+	if retry := newRunID("load", 2); retry == first {
+		t.Error("next attempt got the same run ID")
+	}
+
+	if other := newRunID("transform", 1); other == first {
+		t.Error("other job got the same run ID")
+	}
+
+	_, unkeyed := client.NewRun(context.Background(), "load")
+	if unkeyed.RunID().Version() != 7 {
+		t.Errorf("run without key has version %d, want 7", unkeyed.RunID().Version())
+	}
+}
+
+func Test_RandomRunID(t *testing.T) {
+	ids := oltest.NewIDSequence()
+	olc, _ := oltest.NewClient("test", openlineage.WithIDGenerator(ids))
+	client := run.NewClient(olc, run.WithRunIDStrategy(run.RandomRunID()))
+
+	for _, want := range []string{"00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000002"} {
+		if _, r := client.NewRun(context.Background(), "load"); r.RunID().String() != want {
+			t.Errorf("RunID() = %s, want %s from the client's IDGenerator", r.RunID(), want)
+		}
+	}
+}
+
+func Test_WithRunID(t *testing.T) {
+	client, _ := newTestClient()
+	want := uuid.New()
+
+	_, r := client.NewRun(context.Background(), "job", run.WithRunID(want))
+	if r.RunID() != want {
+		t.Errorf("RunID() = %s, want %s", r.RunID(), want)
+	}
+
+	// a nil ID is ignored, so the strategy still applies
+	generated := uuid.MustParse("0190e8b5-5f4e-7a4c-a1a4-16d4ba5c1b8c")
+	client, _ = newTestClient(run.WithRunIDStrategy(func(string, string, string) uuid.UUID { return generated }))
+
+	if _, r := client.NewRun(context.Background(), "job", run.WithRunID(uuid.Nil)); r.RunID() != generated {
+		t.Errorf("RunID() with WithRunID(uuid.Nil) = %s, want %s", r.RunID(), generated)
+	}
+}
+
+func Test_WithSchedule_NominalTime(t *testing.T) {
+	client, _ := newTestClient()
+
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	_, r := client.NewRun(context.Background(), "job", run.WithSchedule(start, end, 1))
+
+	for _, eventType := range []openlineage.EventType{openlineage.EventTypeStart, openlineage.EventTypeComplete} {
+		event := r.NewEvent(eventType)
+		if event.Run.Facets == nil || event.Run.Facets.NominalTime == nil {
+			t.Fatalf("%s event has no nominal time", eventType)
+		}
+
+		nt := event.Run.Facets.NominalTime
+		if nt.NominalStartTime != "2024-07-01T00:00:00Z" {
+			t.Errorf("nominalStartTime = %s", nt.NominalStartTime)
+		}
+
+		if nt.NominalEndTime == nil || *nt.NominalEndTime != "2024-07-01T01:00:00Z" {
+			t.Errorf("nominalEndTime = %v", nt.NominalEndTime)
+		}
+	}
+}
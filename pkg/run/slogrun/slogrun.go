@@ -0,0 +1,133 @@
+// Package slogrun connects log/slog to the run package.
+//
+// The [Handler] in this package records errors that are logged with the context of a Run on that Run,
+// so that it is reported as failed when it finishes.
+// To write lineage events to slog, use the slog transport in package transport.
+package slogrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+)
+
+var _ slog.Handler = (*Handler)(nil)
+
+// Option configures a [Handler].
+type Option func(*Handler)
+
+// WithLevel sets the minimum level of records that are recorded as errors. Defaults to [slog.LevelError].
+func WithLevel(level slog.Leveler) Option {
+	return func(h *Handler) {
+		h.level = level
+	}
+}
+
+// Handler is a [slog.Handler] that calls [run.Run.RecordError] on [run.FromContext] for error records,
+// before passing all records on to the wrapped handler.
+//
+// The recorded error is the first attribute of the record holding an error, including attributes added with [slog.Logger.With],
+// prefixed with the message of the record. Without such an attribute, the message itself is recorded.
+type Handler struct {
+	next  slog.Handler
+	level slog.Leveler
+
+	// err is the first error in the attributes added with WithAttrs
+	err error
+}
+
+// NewHandler creates a Handler wrapping next.
+func NewHandler(next slog.Handler, opts ...Option) *Handler {
+	h := &Handler{
+		next:  next,
+		level: slog.LevelError,
+	}
+
+	for _, o := range opts {
+		o(h)
+	}
+
+	return h
+}
+
+// Enabled implements slog.Handler.
+// Records that are recorded as errors are always enabled, even if the wrapped handler discards them.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() || h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level >= h.level.Level() {
+		run.FromContext(ctx).RecordError(h.recordError(r))
+	}
+
+	if !h.next.Enabled(ctx, r.Level) {
+		return nil
+	}
+
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+
+	if h2.err == nil {
+		h2.err = findError(attrs)
+	}
+
+	return &h2
+}
+
+// WithGroup implements slog.Handler.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+
+	return &h2
+}
+
+// recordError returns the error to record for r.
+func (h *Handler) recordError(r slog.Record) error {
+	var attrs []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+
+	err := findError(attrs)
+	if err == nil {
+		err = h.err
+	}
+
+	if err == nil {
+		return errors.New(r.Message)
+	}
+
+	return fmt.Errorf("%s: %w", r.Message, err)
+}
+
+// findError returns the first error in attrs, searching groups depth-first.
+func findError(attrs []slog.Attr) error {
+	for _, a := range attrs {
+		v := a.Value.Resolve()
+
+		switch v.Kind() {
+		case slog.KindAny:
+			if err, ok := v.Any().(error); ok {
+				return err
+			}
+		case slog.KindGroup:
+			if err := findError(v.Group()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
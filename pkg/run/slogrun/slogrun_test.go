@@ -0,0 +1,169 @@
+package slogrun_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/oltest"
+	"github.com/ThijsKoot/openlineage-go/pkg/run"
+	"github.com/ThijsKoot/openlineage-go/pkg/run/slogrun"
+)
+
+func Test_Handler(t *testing.T) {
+	diskFull := errors.New("disk full")
+
+	tests := []struct {
+		name        string
+		opts        []slogrun.Option
+		log         func(ctx context.Context, logger *slog.Logger)
+		wantMessage string
+	}{
+		{
+			name: "error-attribute",
+			log: func(ctx context.Context, logger *slog.Logger) {
+				logger.ErrorContext(ctx, "load failed", "table", "users", "error", diskFull)
+			},
+			wantMessage: "load failed: disk full",
+		},
+		{
+			name: "message-only",
+			log: func(ctx context.Context, logger *slog.Logger) {
+				logger.ErrorContext(ctx, "load failed")
+			},
+			wantMessage: "load failed",
+		},
+		{
+			name: "with-attrs",
+			log: func(ctx context.Context, logger *slog.Logger) {
+				logger.With("err", diskFull).WithGroup("load").ErrorContext(ctx, "load failed")
+			},
+			wantMessage: "load failed: disk full",
+		},
+		{
+			name: "group",
+			log: func(ctx context.Context, logger *slog.Logger) {
+				logger.ErrorContext(ctx, "load failed", slog.Group("details", "cause", diskFull))
+			},
+			wantMessage: "load failed: disk full",
+		},
+		{
+			name: "warning",
+			log: func(ctx context.Context, logger *slog.Logger) {
+				logger.WarnContext(ctx, "load slow", "error", diskFull)
+			},
+		},
+		{
+			name: "warning-with-level",
+			opts: []slogrun.Option{slogrun.WithLevel(slog.LevelWarn)},
+			log: func(ctx context.Context, logger *slog.Logger) {
+				logger.WarnContext(ctx, "load slow")
+			},
+			wantMessage: "load slow",
+		},
+		{
+			name: "without-run",
+			log: func(_ context.Context, logger *slog.Logger) {
+				logger.ErrorContext(context.Background(), "load failed", "error", diskFull)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			olc, rec := oltest.NewClient("test")
+			ctx, r := run.NewClient(olc).StartRun(context.Background(), "load")
+
+			var buf bytes.Buffer
+			logger := slog.New(slogrun.NewHandler(slog.NewTextHandler(&buf, nil), tt.opts...))
+
+			tt.log(ctx, logger)
+
+			if !strings.Contains(buf.String(), "msg=") {
+				t.Error("record was not passed on to the wrapped handler")
+			}
+
+			if r.HasFailed() != (tt.wantMessage != "") {
+				t.Fatalf("HasFailed() = %t, want %t", r.HasFailed(), tt.wantMessage != "")
+			}
+
+			r.Finish()
+
+			if tt.wantMessage == "" {
+				return
+			}
+
+			waitCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			events, err := rec.WaitForEvents(waitCtx, 1, oltest.ByEventType(openlineage.EventTypeFail))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			facets := events[0].Run.Facets
+			if facets == nil || facets.ErrorMessage == nil || facets.ErrorMessage.Message != tt.wantMessage {
+				t.Errorf("expected error message %q, got %+v", tt.wantMessage, facets)
+			}
+		})
+	}
+}
+
+func Test_Handler_Enabled(t *testing.T) {
+	next := slog.NewTextHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.Level(100)})
+	h := slogrun.NewHandler(next)
+
+	if !h.Enabled(context.Background(), slog.LevelError) {
+		t.Error("error records must be enabled even if the wrapped handler discards them")
+	}
+
+	if h.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("info records should follow the wrapped handler")
+	}
+}
+
+func Test_Handler_Concurrent(t *testing.T) {
+	olc, rec := oltest.NewClient("test")
+	client := run.NewClient(olc)
+	ctx, r := client.StartRun(context.Background(), "load")
+
+	logger := slog.New(slogrun.NewHandler(slog.NewTextHandler(io.Discard, nil)))
+
+	const goroutines = 8
+
+	var wg sync.WaitGroup
+	for i := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.ErrorContext(ctx, "partition failed", "partition", i)
+			_ = r.HasFailed()
+		}()
+	}
+	wg.Wait()
+
+	r.Finish()
+
+	flushCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := client.Flush(flushCtx); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(oltest.Select(rec.Events(), oltest.ByEventType(openlineage.EventTypeOther))); n != goroutines {
+		t.Errorf("recorded %d errors, want %d", n, goroutines)
+	}
+
+	fail := oltest.Select(rec.Events(), oltest.ByEventType(openlineage.EventTypeFail))
+	if len(fail) != 1 || fail[0].Run.Facets == nil || fail[0].Run.Facets.ErrorMessage == nil {
+		t.Errorf("expected a FAIL event with an error message, got %+v", fail)
+	}
+}
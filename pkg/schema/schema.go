@@ -0,0 +1,188 @@
+// Package schema validates OpenLineage payloads against the JSON schema of the specification.
+//
+// The schema is embedded for the OpenLineage release the types of this module are generated from,
+// so validation needs no network access. Facets are checked for the fields every facet has,
+// not against the schemas of the individual facets.
+package schema
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// URL is the identifier of the embedded OpenLineage schema.
+const URL = "https://openlineage.io/spec/2-0-2/OpenLineage.json"
+
+//go:embed spec/OpenLineage.json
+var openLineageSchema []byte
+
+var printer = message.NewPrinter(language.English)
+
+// schemas holds the compiled schema for any event, and for each kind of event keyed by its definition.
+type schemas struct {
+	event *jsonschema.Schema
+	kinds map[string]*jsonschema.Schema
+}
+
+var compiled = sync.OnceValues(func() (schemas, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(openLineageSchema))
+	if err != nil {
+		return schemas{}, fmt.Errorf("decode schema: %w", err)
+	}
+
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource(URL, doc); err != nil {
+		return schemas{}, fmt.Errorf("add schema: %w", err)
+	}
+
+	s := schemas{kinds: map[string]*jsonschema.Schema{}}
+	if s.event, err = c.Compile(URL); err != nil {
+		return schemas{}, fmt.Errorf("compile schema: %w", err)
+	}
+
+	for _, def := range []string{"RunEvent", "JobEvent", "DatasetEvent"} {
+		if s.kinds[def], err = c.Compile(URL + "#/$defs/" + def); err != nil {
+			return schemas{}, fmt.Errorf("compile schema of %s: %w", def, err)
+		}
+	}
+
+	return s, nil
+})
+
+// Validate checks a payload of the OpenLineage HTTP endpoint against the schema:
+// a single event as a JSON object, or a batch of events as a JSON array.
+// Problems with events in a batch are prefixed with the index of the event.
+func Validate(payload []byte) error {
+	payload = bytes.TrimSpace(payload)
+	if len(payload) == 0 || payload[0] != '[' {
+		return ValidateEvent(payload)
+	}
+
+	var events []json.RawMessage
+	if err := json.Unmarshal(payload, &events); err != nil {
+		return fmt.Errorf("decode batch: %w", err)
+	}
+
+	var errs []error
+	for i, e := range events {
+		if err := ValidateEvent(e); err != nil {
+			errs = append(errs, fmt.Errorf("event %d: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateEvent checks a JSON-encoded event against the schema.
+// All problems are reported at once as [openlineage.FieldErrors], with the path to the field they were found in.
+func ValidateEvent(event []byte) error {
+	s, err := compiled()
+	if err != nil {
+		return err
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(event))
+	if err != nil {
+		return fmt.Errorf("decode event: %w", err)
+	}
+
+	verr := s.event.Validate(doc)
+	if verr == nil {
+		return nil
+	}
+
+	// The schema accepts one of the kinds of events, so its errors list the problems for every kind.
+	// Report those of the kind the event looks like instead, unless it is valid on its own.
+	if k, ok := s.kinds[eventKind(doc)]; ok {
+		if err := k.Validate(doc); err != nil {
+			verr = err
+		}
+	}
+
+	var ve *jsonschema.ValidationError
+	if !errors.As(verr, &ve) {
+		return verr
+	}
+
+	var errs openlineage.FieldErrors
+	collect(&errs, ve)
+
+	// the order of the causes depends on the iteration order of the properties
+	slices.SortStableFunc(errs, func(a, b openlineage.FieldError) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return errs
+}
+
+// eventKind returns the schema definition of the kind of event doc looks like,
+// using the same fields as [openlineage.ValidateEvent].
+func eventKind(doc any) string {
+	fields, _ := doc.(map[string]any)
+
+	switch {
+	case fields["run"] != nil || fields["eventType"] != nil:
+		return "RunEvent"
+	case fields["dataset"] != nil:
+		return "DatasetEvent"
+	case fields["job"] != nil:
+		return "JobEvent"
+	}
+
+	return ""
+}
+
+// collect adds the errors without further causes in ve to errs.
+func collect(errs *openlineage.FieldErrors, ve *jsonschema.ValidationError) {
+	if len(ve.Causes) > 0 {
+		for _, c := range ve.Causes {
+			collect(errs, c)
+		}
+
+		return
+	}
+
+	path := fieldPath(ve.InstanceLocation)
+
+	if required, ok := ve.ErrorKind.(*kind.Required); ok {
+		for _, field := range required.Missing {
+			*errs = append(*errs, openlineage.FieldError{Path: joinPath(path, field), Message: "is required"})
+		}
+
+		return
+	}
+
+	*errs = append(*errs, openlineage.FieldError{Path: path, Message: ve.ErrorKind.LocalizedString(printer)})
+}
+
+// fieldPath formats a location within an event like the paths of [openlineage.FieldError], e.g. "inputs[0].name".
+func fieldPath(location []string) string {
+	var path string
+	for _, token := range location {
+		if _, err := strconv.Atoi(token); err == nil {
+			path += "[" + token + "]"
+			continue
+		}
+
+		path = joinPath(path, token)
+	}
+
+	return path
+}
+
+func joinPath(path, field string) string {
+	return strings.TrimPrefix(path+"."+field, ".")
+}
@@ -0,0 +1,117 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
+	"github.com/ThijsKoot/openlineage-go/pkg/schema"
+	"github.com/go-test/deep"
+	"github.com/google/uuid"
+)
+
+func Test_ValidateEvent_SDKEvents(t *testing.T) {
+	runID := uuid.Must(uuid.NewV7())
+	dataset := openlineage.NewDatasetEvent("users", "warehouse")
+
+	events := map[string]openlineage.Emittable{
+		"run": openlineage.NewRunEvent(openlineage.EventTypeStart, runID, "load").
+			WithRunFacets(facets.NewNominalTime("2024-07-01T00:00:00Z")).
+			WithInputs(openlineage.InputElement{Name: "users", Namespace: "warehouse"}),
+		"child": openlineage.NewRunEvent(openlineage.EventTypeComplete, uuid.Must(uuid.NewV7()), "step").
+			WithRunFacets(facets.NewParent(facets.Job{Name: "load", Namespace: "default"}, facets.Run{RunID: runID.String()})),
+		"job":     openlineage.NewJobEvent("load"),
+		"dataset": &dataset,
+	}
+
+	for name, e := range events {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(e.AsEmittable())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := schema.ValidateEvent(data); err != nil {
+				t.Errorf("ValidateEvent(%s) = %s", data, err)
+			}
+		})
+	}
+}
+
+func Test_ValidateEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		event string
+		want  openlineage.FieldErrors
+	}{
+		{
+			name: "missing-fields",
+			event: `{"eventTime": "2024-07-01T00:00:00Z", "producer": "p", "schemaURL": "s",
+				"eventType": "START", "run": {}, "job": {"name": "load"}}`,
+			want: openlineage.FieldErrors{
+				{Path: "job.namespace", Message: "is required"},
+				{Path: "run.runId", Message: "is required"},
+			},
+		},
+		{
+			name: "event-type",
+			event: `{"eventTime": "2024-07-01T00:00:00Z", "producer": "p", "schemaURL": "s",
+				"eventType": "DONE", "run": {"runId": "0190e8b5-5f4e-7a4c-a1a4-16d4ba5c1b8c"}, "job": {"name": "load", "namespace": "ns"}}`,
+			want: openlineage.FieldErrors{
+				{Path: "eventType", Message: `value must be one of 'START', 'RUNNING', 'COMPLETE', 'ABORT', 'FAIL', 'OTHER'`},
+			},
+		},
+		{
+			name: "facet",
+			event: `{"eventTime": "2024-07-01T00:00:00Z", "producer": "p", "schemaURL": "s",
+				"run": {"runId": "0190e8b5-5f4e-7a4c-a1a4-16d4ba5c1b8c", "facets": {"custom": {"_producer": "p"}}},
+				"job": {"name": "load", "namespace": "ns"},
+				"inputs": [{"name": "users", "namespace": 1}]}`,
+			want: openlineage.FieldErrors{
+				{Path: "inputs[0].namespace", Message: "got number, want string"},
+				{Path: "run.facets.custom._schemaURL", Message: "is required"},
+			},
+		},
+		{
+			name: "dataset-event",
+			event: `{"eventTime": "2024-07-01T00:00:00Z", "producer": "p", "schemaURL": "s",
+				"dataset": {"name": "users"}}`,
+			want: openlineage.FieldErrors{
+				{Path: "dataset.namespace", Message: "is required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.ValidateEvent([]byte(tt.event))
+
+			var got openlineage.FieldErrors
+			if !errors.As(err, &got) {
+				t.Fatalf("ValidateEvent() = %v, want FieldErrors", err)
+			}
+
+			if diff := deep.Equal(tt.want, got); diff != nil {
+				t.Errorf("differences found:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Validate_Batch(t *testing.T) {
+	valid, err := json.Marshal(openlineage.NewJobEvent("load").AsEmittable())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := schema.Validate([]byte("[" + string(valid) + "," + string(valid) + "]")); err != nil {
+		t.Errorf("valid batch: %s", err)
+	}
+
+	err = schema.Validate([]byte("[" + string(valid) + `, {"job": {"name": "load", "namespace": "ns"}}]`))
+	if want := "event 1: eventTime: is required; producer: is required; schemaURL: is required"; err == nil || err.Error() != want {
+		t.Errorf("Validate() = %v, want %s", err, want)
+	}
+}
@@ -1,34 +1,164 @@
 package transport
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"sync"
 
 	"github.com/tidwall/pretty"
 )
 
+// ConsoleFormat is the format in which the console transport writes events.
+type ConsoleFormat string
+
+const (
+	// ConsoleFormatCompact writes each event as JSON on a single line.
+	ConsoleFormatCompact ConsoleFormat = "compact"
+	// ConsoleFormatPretty writes each event as indented JSON.
+	ConsoleFormatPretty ConsoleFormat = "pretty"
+	// ConsoleFormatSummary writes a line per event, such as "START namespace/job run=<id> inputs=2 outputs=1".
+	// Values that cannot be summarized are written as compact JSON.
+	ConsoleFormatSummary ConsoleFormat = "summary"
+)
+
+// Console targets, see [ConsoleConfig].
+const (
+	ConsoleTargetStdout = "stdout"
+	ConsoleTargetStderr = "stderr"
+)
+
 type ConsoleConfig struct {
+	// Pretty-print events, if Format is not set
 	PrettyPrint bool `yaml:"prettyPrint" env:"OPENLINEAGE_PRETTY_PRINT,overwrite"`
+
+	// Format of events: compact, pretty or summary (default: compact, or pretty if PrettyPrint is set)
+	Format ConsoleFormat `yaml:"format" env:"OPENLINEAGE_CONSOLE_FORMAT,overwrite"`
+
+	// Target events are written to: stdout or stderr (default: stdout)
+	Target string `yaml:"target" env:"OPENLINEAGE_CONSOLE_TARGET,overwrite"`
+
+	// Writer events are written to instead of Target. It can only be set in code.
+	Writer io.Writer `yaml:"-"`
+}
+
+// ConsoleFieldError reports an invalid field of a [ConsoleConfig].
+type ConsoleFieldError struct {
+	// Field is the YAML name of the field
+	Field   string
+	Message string
+}
+
+func (e *ConsoleFieldError) Error() string {
+	return fmt.Sprintf("console %s: %s", e.Field, e.Message)
 }
 
+// Validate checks the format and target.
+// Each invalid field is reported as a [*ConsoleFieldError], joined with [errors.Join].
+func (c ConsoleConfig) Validate() error {
+	var errs []error
+
+	switch c.Format {
+	case "", ConsoleFormatCompact, ConsoleFormatPretty, ConsoleFormatSummary:
+	default:
+		errs = append(errs, &ConsoleFieldError{
+			Field: "format",
+			Message: fmt.Sprintf("unknown format %q, expected one of: %s, %s, %s",
+				c.Format, ConsoleFormatCompact, ConsoleFormatPretty, ConsoleFormatSummary),
+		})
+	}
+
+	switch c.Target {
+	case "", ConsoleTargetStdout, ConsoleTargetStderr:
+	default:
+		errs = append(errs, &ConsoleFieldError{
+			Field:   "target",
+			Message: fmt.Sprintf("unknown target %q, expected one of: %s, %s", c.Target, ConsoleTargetStdout, ConsoleTargetStderr),
+		})
+	}
+
+	return errors.Join(errs...)
+}
+
+var _ Transport = (*consoleTransport)(nil)
+
 type consoleTransport struct {
-	prettyPrint bool
+	format ConsoleFormat
+
+	// mu serializes writes, so that events emitted concurrently are not interleaved
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newConsoleTransport(config *ConsoleConfig) (*consoleTransport, error) {
+	if config == nil {
+		config = &ConsoleConfig{}
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	ct := &consoleTransport{
+		format: config.Format,
+		w:      config.Writer,
+	}
+
+	if ct.format == "" {
+		ct.format = ConsoleFormatCompact
+		if config.PrettyPrint {
+			ct.format = ConsoleFormatPretty
+		}
+	}
+
+	if ct.w == nil {
+		ct.w = os.Stdout
+		if config.Target == ConsoleTargetStderr {
+			ct.w = os.Stderr
+		}
+	}
+
+	return ct, nil
 }
 
 func (ct *consoleTransport) Emit(ctx context.Context, event any) error {
+	body, err := ct.render(event)
+	if err != nil {
+		return err
+	}
+
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if _, err := ct.w.Write(body); err != nil {
+		return fmt.Errorf("emit event to console: %w", err)
+	}
+
+	return nil
+}
+
+// render formats event as a single newline-terminated write.
+func (ct *consoleTransport) render(event any) ([]byte, error) {
+	if s, ok := event.(interface{ Summary() string }); ok && ct.format == ConsoleFormatSummary {
+		return []byte(s.Summary() + "\n"), nil
+	}
+
 	body, err := json.Marshal(&event)
 	if err != nil {
-		return fmt.Errorf("marshal event: %w", err)
+		return nil, fmt.Errorf("marshal event: %w", err)
 	}
 
-	if ct.prettyPrint {
+	if ct.format == ConsoleFormatPretty {
 		body = pretty.Pretty(body)
 	}
 
-	if _, err := fmt.Println(string(body)); err != nil {
-		return fmt.Errorf("emit event to console: %w", err)
+	if !bytes.HasSuffix(body, []byte("\n")) {
+		body = append(body, '\n')
 	}
 
-	return nil
+	return body, nil
 }
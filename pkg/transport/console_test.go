@@ -0,0 +1,156 @@
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/transport"
+	"github.com/google/uuid"
+)
+
+func Test_Console(t *testing.T) {
+	runID := uuid.MustParse("0190b5c8-3f1e-7a9b-8c4d-5e6f7a8b9c0d")
+	event := openlineage.NewNamespacedRunEvent(openlineage.EventTypeStart, runID, "load", "ns").
+		WithInputs(openlineage.NewInputElement("a", "db"), openlineage.NewInputElement("b", "db")).
+		WithOutputs(openlineage.NewOutputElement("c", "db")).
+		AsEmittable()
+
+	tests := []struct {
+		name   string
+		config transport.ConsoleConfig
+		check  func(t *testing.T, out string)
+	}{
+		{
+			name: "compact",
+			check: func(t *testing.T, out string) {
+				if strings.Count(out, "\n") != 1 || !json.Valid([]byte(out)) {
+					t.Errorf("expected a single line of JSON, got %q", out)
+				}
+			},
+		},
+		{
+			name:   "pretty",
+			config: transport.ConsoleConfig{Format: transport.ConsoleFormatPretty},
+			check: func(t *testing.T, out string) {
+				if !strings.HasPrefix(out, "{\n  ") || !strings.HasSuffix(out, "}\n") || !json.Valid([]byte(out)) {
+					t.Errorf("expected indented JSON, got %q", out)
+				}
+			},
+		},
+		{
+			name:   "pretty-print",
+			config: transport.ConsoleConfig{PrettyPrint: true},
+			check: func(t *testing.T, out string) {
+				if !strings.HasPrefix(out, "{\n  ") {
+					t.Errorf("expected indented JSON, got %q", out)
+				}
+			},
+		},
+		{
+			name:   "summary",
+			config: transport.ConsoleConfig{Format: transport.ConsoleFormatSummary},
+			check: func(t *testing.T, out string) {
+				want := "START ns/load run=" + runID.String() + " inputs=2 outputs=1\n"
+				if out != want {
+					t.Errorf("got %q, want %q", out, want)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.config.Writer = &buf
+
+			ct, err := transport.New(transport.Config{Type: transport.TransportTypeConsole, Console: &tt.config})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := ct.Emit(context.Background(), event); err != nil {
+				t.Fatal(err)
+			}
+
+			tt.check(t, buf.String())
+		})
+	}
+}
+
+func Test_Console_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		config transport.ConsoleConfig
+		field  string
+	}{
+		{config: transport.ConsoleConfig{Format: "yaml"}, field: "format"},
+		{config: transport.ConsoleConfig{Target: "syslog"}, field: "target"},
+	}
+
+	for _, tt := range tests {
+		_, err := transport.New(transport.Config{Type: transport.TransportTypeConsole, Console: &tt.config})
+
+		var fieldErr *transport.ConsoleFieldError
+		if !errors.As(err, &fieldErr) || fieldErr.Field != tt.field {
+			t.Errorf("expected an error for field %s of %+v, got %v", tt.field, tt.config, err)
+		}
+	}
+}
+
+// chunkedWriter writes every call in small chunks, so that unsynchronized writes would interleave.
+type chunkedWriter struct {
+	w io.Writer
+}
+
+func (c chunkedWriter) Write(p []byte) (int, error) {
+	for i := 0; i < len(p); i += 8 {
+		if _, err := c.w.Write(p[i:min(i+8, len(p))]); err != nil {
+			return i, err
+		}
+	}
+
+	return len(p), nil
+}
+
+func Test_Console_Concurrent(t *testing.T) {
+	var buf bytes.Buffer
+
+	ct, err := transport.New(transport.Config{
+		Type:    transport.TransportTypeConsole,
+		Console: &transport.ConsoleConfig{Format: transport.ConsoleFormatPretty, Writer: chunkedWriter{w: &buf}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 50
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			event := openlineage.NewRunEvent(openlineage.EventTypeRunning, uuid.New(), "job").AsEmittable()
+			if err := ct.Emit(context.Background(), event); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	dec := json.NewDecoder(&buf)
+	for i := 0; i < n; i++ {
+		var event openlineage.Event
+		if err := dec.Decode(&event); err != nil {
+			t.Fatalf("event %d is not valid JSON: %s", i, err)
+		}
+	}
+}
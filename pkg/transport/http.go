@@ -64,8 +64,18 @@ func (h *httpTransport) Emit(ctx context.Context, event any) error {
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("server responded with status %v: %s", resp.StatusCode, body)
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	return nil
 }
+
+// StatusError is returned by the HTTP transport when the server does not accept an event.
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("server responded with status %v: %s", e.StatusCode, e.Body)
+}
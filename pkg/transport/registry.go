@@ -0,0 +1,100 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/sethvargo/go-envconfig"
+)
+
+// Factory creates a transport of a registered type.
+//
+// decode unmarshals the configuration section named after the type, e.g. "transport.kafka" for type "kafka", into v.
+// Environment variables are applied afterwards according to the env tags of v, as for the built-in transports.
+type Factory func(decode func(v any) error) (Transport, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[TransportType]Factory{}
+)
+
+// Register makes a transport type available to [New], and to configuration read from files or the environment.
+// It is meant to be called from an init function.
+// Register panics if factory is nil, or if typ is empty, built in or already registered.
+func Register(typ TransportType, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	switch {
+	case factory == nil:
+		panic("transport: Register factory is nil")
+	case typ == "":
+		panic("transport: Register type is empty")
+	case typ == TransportTypeConsole || typ == TransportTypeHTTP || typ == TransportTypeSlog:
+		panic(fmt.Sprintf("transport: Register called for built-in type %s", typ))
+	}
+
+	if _, dup := registry[typ]; dup {
+		panic(fmt.Sprintf("transport: Register called twice for type %s", typ))
+	}
+
+	registry[typ] = factory
+}
+
+// Types returns the built-in and registered transport types, sorted by name.
+func Types() []TransportType {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	types := []TransportType{TransportTypeConsole, TransportTypeHTTP, TransportTypeSlog}
+	for t := range registry {
+		types = append(types, t)
+	}
+
+	slices.Sort(types)
+
+	return types
+}
+
+// IsRegistered reports whether typ was registered with [Register].
+func IsRegistered(typ TransportType) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	_, ok := registry[typ]
+
+	return ok
+}
+
+func newRegistered(config Config) (Transport, error) {
+	registryMu.RLock()
+	factory, ok := registry[config.Type]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown transport type %q", config.Type)
+	}
+
+	decode := func(v any) error {
+		if section, ok := config.Sections[string(config.Type)]; ok {
+			if err := section.Decode(v); err != nil {
+				return fmt.Errorf("decode transport.%s: %w", config.Type, err)
+			}
+		}
+
+		if err := envconfig.Process(context.Background(), v); err != nil {
+			return fmt.Errorf("parse transport.%s from environment: %w", config.Type, err)
+		}
+
+		return nil
+	}
+
+	t, err := factory(decode)
+	if err != nil {
+		return nil, fmt.Errorf("create %s transport: %w", config.Type, err)
+	}
+
+	return t, nil
+}
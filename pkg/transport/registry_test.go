@@ -0,0 +1,167 @@
+package transport_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/transport"
+	"github.com/go-test/deep"
+	"github.com/google/uuid"
+)
+
+type busConfig struct {
+	Topic   string   `yaml:"topic" env:"BUS_TOPIC,overwrite"`
+	Brokers []string `yaml:"brokers"`
+}
+
+type busTransport struct {
+	config busConfig
+	events []any
+}
+
+func (b *busTransport) Emit(_ context.Context, event any) error {
+	b.events = append(b.events, event)
+	return nil
+}
+
+// created is the transport most recently created by the bus factory
+var created *busTransport
+
+func init() {
+	transport.Register("bus", func(decode func(v any) error) (transport.Transport, error) {
+		var cfg busConfig
+		if err := decode(&cfg); err != nil {
+			return nil, err
+		}
+
+		created = &busTransport{config: cfg}
+
+		return created, nil
+	})
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "openlineage.yml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func Test_Register(t *testing.T) {
+	const file = `
+transport:
+  type: bus
+  bus:
+    topic: ${BUS_TOPIC_NAME}
+    brokers: [a:9092, b:9092]
+`
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		file string
+		want busConfig
+	}{
+		{
+			name: "file",
+			env:  map[string]string{"BUS_TOPIC_NAME": "lineage"},
+			file: file,
+			want: busConfig{Topic: "lineage", Brokers: []string{"a:9092", "b:9092"}},
+		},
+		{
+			name: "file-and-env",
+			env:  map[string]string{"BUS_TOPIC": "override"},
+			file: file,
+			want: busConfig{Topic: "override", Brokers: []string{"a:9092", "b:9092"}},
+		},
+		{
+			name: "env",
+			env:  map[string]string{"OPENLINEAGE_TRANSPORT": "bus", "BUS_TOPIC": "from-env"},
+			want: busConfig{Topic: "from-env"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			if tt.file != "" {
+				t.Setenv("OPENLINEAGE_CONFIG", writeConfig(t, tt.file))
+			}
+
+			cfg, err := openlineage.ConfigFromEnv()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client, err := openlineage.NewClient(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := deep.Equal(tt.want, created.config); diff != nil {
+				t.Errorf("differences found:\n%s", diff)
+			}
+
+			if err := client.Emit(context.Background(), client.NewRunEvent(openlineage.EventTypeStart, uuid.New(), "job")); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(created.events) != 1 {
+				t.Errorf("expected 1 event, got %d", len(created.events))
+			}
+		})
+	}
+}
+
+func Test_Register_Types(t *testing.T) {
+	want := []transport.TransportType{"bus", transport.TransportTypeConsole, transport.TransportTypeHTTP, transport.TransportTypeSlog}
+	if diff := deep.Equal(want, transport.Types()); diff != nil {
+		t.Errorf("differences found:\n%s", diff)
+	}
+
+	cfg := openlineage.ClientConfig{Transport: transport.Config{Type: "kafka"}}
+	if err := cfg.Validate(); err == nil || err.Error() != `transport.type: unknown transport type "kafka", expected one of: bus, console, http, slog` {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := openlineage.ConfigFromFile(writeConfig(t, "transport:\n  type: kafka\n  kafka:\n    topic: x\n")); err == nil {
+		t.Error("expected an error for the section of an unregistered transport")
+	}
+}
+
+func Test_Register_Panics(t *testing.T) {
+	factory := func(func(any) error) (transport.Transport, error) { return nil, nil }
+
+	tests := []struct {
+		name    string
+		typ     transport.TransportType
+		factory transport.Factory
+	}{
+		{name: "duplicate", typ: "bus", factory: factory},
+		{name: "built-in", typ: transport.TransportTypeHTTP, factory: factory},
+		{name: "empty", typ: "", factory: factory},
+		{name: "nil-factory", typ: "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Register did not panic")
+				}
+			}()
+
+			transport.Register(tt.typ, tt.factory)
+		})
+	}
+}
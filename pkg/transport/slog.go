@@ -0,0 +1,134 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
+// SlogMessage is the message of records written by the slog transport.
+const SlogMessage = "openlineage event"
+
+type SlogConfig struct {
+	// Level of the records: debug, info, warn or error (default: info)
+	Level string `yaml:"level" env:"OPENLINEAGE_SLOG_LEVEL,overwrite"`
+
+	// Logger records are written to instead of [slog.Default]. It can only be set in code.
+	Logger *slog.Logger `yaml:"-"`
+}
+
+var _ Transport = (*slogTransport)(nil)
+
+// slogTransport writes events as structured log records.
+type slogTransport struct {
+	level  slog.Level
+	logger *slog.Logger
+}
+
+func newSlogTransport(config *SlogConfig) (*slogTransport, error) {
+	if config == nil {
+		config = &SlogConfig{}
+	}
+
+	st := &slogTransport{logger: config.Logger}
+
+	if config.Level != "" {
+		if err := st.level.UnmarshalText([]byte(config.Level)); err != nil {
+			return nil, fmt.Errorf("parse slog level: %w", err)
+		}
+	}
+
+	return st, nil
+}
+
+// slogEvent contains the fields of an event that are written as attributes.
+type slogEvent struct {
+	EventType string `json:"eventType"`
+	EventTime string `json:"eventTime"`
+	Run       *struct {
+		RunID string `json:"runId"`
+	} `json:"run"`
+	Job     *slogDataset  `json:"job"`
+	Dataset *slogDataset  `json:"dataset"`
+	Inputs  []slogDataset `json:"inputs"`
+	Outputs []slogDataset `json:"outputs"`
+}
+
+// slogDataset is the name of a job or dataset.
+type slogDataset struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+func (d slogDataset) String() string {
+	return d.Namespace + "/" + d.Name
+}
+
+func (st *slogTransport) Emit(ctx context.Context, event any) error {
+	body, err := json.Marshal(&event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	var e slogEvent
+	if err := json.Unmarshal(body, &e); err != nil {
+		return fmt.Errorf("decode event: %w", err)
+	}
+
+	logger := st.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	logger.LogAttrs(ctx, st.level, SlogMessage, e.attrs()...)
+
+	return nil
+}
+
+func (e slogEvent) attrs() []slog.Attr {
+	var attrs []slog.Attr
+
+	if e.EventType != "" {
+		attrs = append(attrs, slog.String("eventType", e.EventType))
+	}
+
+	attrs = append(attrs, slog.String("eventTime", e.EventTime))
+
+	if e.Job != nil {
+		attrs = append(attrs, slog.Group("job",
+			slog.String("namespace", e.Job.Namespace),
+			slog.String("name", e.Job.Name),
+		))
+	}
+
+	if e.Run != nil {
+		attrs = append(attrs, slog.String("runId", e.Run.RunID))
+	}
+
+	if e.Dataset != nil {
+		attrs = append(attrs, slog.Group("dataset",
+			slog.String("namespace", e.Dataset.Namespace),
+			slog.String("name", e.Dataset.Name),
+		))
+	}
+
+	if e.Job != nil {
+		attrs = append(attrs,
+			slog.Any("inputs", datasetNames(e.Inputs)),
+			slog.Any("outputs", datasetNames(e.Outputs)),
+		)
+	}
+
+	return attrs
+}
+
+// datasetNames returns datasets as namespace/name.
+func datasetNames(datasets []slogDataset) []string {
+	names := make([]string, len(datasets))
+	for i, d := range datasets {
+		names[i] = d.String()
+	}
+
+	return names
+}
@@ -0,0 +1,103 @@
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/transport"
+	"github.com/go-test/deep"
+	"github.com/google/uuid"
+)
+
+func Test_Slog(t *testing.T) {
+	runID := uuid.MustParse("0190b5c8-3f1e-7a9b-8c4d-5e6f7a8b9c0d")
+	runEvent := openlineage.NewNamespacedRunEvent(openlineage.EventTypeComplete, runID, "load", "ns").
+		WithInputs(openlineage.NewInputElement("users", "postgres://db")).
+		WithOutputs(openlineage.NewOutputElement("users.parquet", "s3://lake")).
+		AsEmittable()
+	runEvent.EventTime = "2024-01-01T00:00:00Z"
+
+	datasetEvent := openlineage.NewDatasetEvent("users", "postgres://db")
+	datasetEvent.EventTime = "2024-01-01T00:00:00Z"
+
+	tests := []struct {
+		name  string
+		level string
+		event openlineage.Event
+		want  map[string]any
+	}{
+		{
+			name:  "run",
+			event: runEvent,
+			want: map[string]any{
+				"level":     "INFO",
+				"msg":       transport.SlogMessage,
+				"eventType": "COMPLETE",
+				"eventTime": "2024-01-01T00:00:00Z",
+				"job":       map[string]any{"namespace": "ns", "name": "load"},
+				"runId":     runID.String(),
+				"inputs":    []any{"postgres://db/users"},
+				"outputs":   []any{"s3://lake/users.parquet"},
+			},
+		},
+		{
+			name:  "dataset",
+			level: "debug",
+			event: datasetEvent.AsEmittable(),
+			want: map[string]any{
+				"level":     "DEBUG",
+				"msg":       transport.SlogMessage,
+				"eventTime": "2024-01-01T00:00:00Z",
+				"dataset":   map[string]any{"namespace": "postgres://db", "name": "users"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+				Level: slog.LevelDebug,
+				ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+					if a.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+
+					return a
+				},
+			}))
+
+			st, err := transport.New(transport.Config{
+				Type: transport.TransportTypeSlog,
+				Slog: &transport.SlogConfig{Level: tt.level, Logger: logger},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := st.Emit(context.Background(), tt.event); err != nil {
+				t.Fatal(err)
+			}
+
+			var got map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("decode record %q: %s", buf.String(), err)
+			}
+
+			if diff := deep.Equal(tt.want, got); diff != nil {
+				t.Errorf("differences found:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Slog_InvalidLevel(t *testing.T) {
+	_, err := transport.New(transport.Config{Type: transport.TransportTypeSlog, Slog: &transport.SlogConfig{Level: "loud"}})
+	if err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
@@ -4,14 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 
+	"github.com/ThijsKoot/openlineage-go/pkg/metrics"
 	"github.com/hashicorp/go-retryablehttp"
+	"gopkg.in/yaml.v3"
 )
 
 const (
 	TransportTypeHTTP    TransportType = "http"
 	TransportTypeConsole TransportType = "console"
+	TransportTypeSlog    TransportType = "slog"
 )
 
 type Transport interface {
@@ -24,16 +28,60 @@ type Config struct {
 	Type    TransportType  `yaml:"type" env:"OPENLINEAGE_TRANSPORT,overwrite"`
 	Console *ConsoleConfig `yaml:"console,omitempty" env:",noinit"`
 	HTTP    *HTTPConfig    `yaml:"http,omitempty" env:",noinit"`
+	Slog    *SlogConfig    `yaml:"slog,omitempty" env:",noinit"`
+
+	// Sections holds the configuration of transport types added with [Register], keyed by type
+	Sections map[string]yaml.Node `yaml:",inline"`
+}
+
+// Option configures the transports created by [New].
+type Option func(*options)
+
+type options struct {
+	name    string
+	metrics metrics.Recorder
+}
+
+// WithName sets the transport name that measurements are reported under, such as the name of a route.
+// Defaults to the transport type.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithMetrics reports measurements taken by transports, such as retries of the HTTP transport, to r.
+func WithMetrics(r metrics.Recorder) Option {
+	return func(o *options) {
+		o.metrics = r
+	}
 }
 
-func New(config Config) (Transport, error) {
+// New creates the transport of the configured type, which is either built in or added with [Register].
+func New(config Config, opts ...Option) (Transport, error) {
+	o := options{name: string(config.Type), metrics: metrics.Noop{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	switch config.Type {
 	case TransportTypeConsole:
-		return &consoleTransport{
-			prettyPrint: config.Console.PrettyPrint,
-		}, nil
+		return newConsoleTransport(config.Console)
 	case TransportTypeHTTP:
-		httpClient := retryablehttp.NewClient().StandardClient()
+		if config.HTTP == nil {
+			return nil, errors.New("no HTTP configuration specified")
+		}
+
+		retryClient := retryablehttp.NewClient()
+		// return the last response once retries are exhausted, so that its status is reported
+		retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+		retryClient.RequestLogHook = func(_ retryablehttp.Logger, req *http.Request, attempt int) {
+			if attempt > 0 {
+				o.metrics.Retried(req.Context(), o.name)
+			}
+		}
+
+		httpClient := retryClient.StandardClient()
 
 		u, err := url.Parse(config.HTTP.URL)
 		if err != nil {
@@ -52,7 +100,11 @@ func New(config Config) (Transport, error) {
 			uri:        u.String(),
 			apiKey:     config.HTTP.APIKey,
 		}, nil
-	default:
+	case TransportTypeSlog:
+		return newSlogTransport(config.Slog)
+	case "":
 		return nil, errors.New("no valid transport specified")
+	default:
+		return newRegistered(config)
 	}
 }
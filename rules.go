@@ -0,0 +1,140 @@
+package openlineage
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ThijsKoot/openlineage-go/pkg/facets"
+)
+
+// Rule selects events by job and namespace, and changes how they are emitted.
+// Patterns match the whole value, where * matches any sequence of characters and ? a single character.
+// An empty pattern matches everything.
+//
+// Only the first rule matching an event applies; the actions of later rules are not combined with it.
+// To route and rename the same events, set both actions on one rule.
+type Rule struct {
+	// Job matches the name of the job. Dataset events have no job and only match rules without one.
+	Job string `yaml:"job,omitempty"`
+
+	// Namespace matches the namespace of the job, or of the dataset for dataset events
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// Disabled drops matching events instead of emitting them
+	Disabled bool `yaml:"disabled,omitempty"`
+
+	// Transport is the name of the entry in [ClientConfig.Transports] matching events are emitted to
+	Transport string `yaml:"transport,omitempty"`
+
+	// SetNamespace replaces the namespace of the job of matching events,
+	// and of the parent and root jobs in the parent facets of other events that refer to a matching job
+	SetNamespace string `yaml:"setNamespace,omitempty"`
+}
+
+// rule is a Rule with its patterns compiled. A nil pattern matches everything.
+type rule struct {
+	Rule
+	job       *regexp.Regexp
+	namespace *regexp.Regexp
+}
+
+func compileRules(rules []Rule) []rule {
+	compiled := make([]rule, len(rules))
+	for i, r := range rules {
+		compiled[i] = rule{
+			Rule:      r,
+			job:       compilePattern(r.Job),
+			namespace: compilePattern(r.Namespace),
+		}
+	}
+
+	return compiled
+}
+
+// compilePattern translates a pattern into an anchored regular expression, see [Rule].
+func compilePattern(pattern string) *regexp.Regexp {
+	if pattern == "" {
+		return nil
+	}
+
+	var b strings.Builder
+	b.WriteString(`(?s)^`)
+
+	for _, c := range pattern {
+		switch c {
+		case '*':
+			b.WriteString(`.*`)
+		case '?':
+			b.WriteString(`.`)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	b.WriteString(`$`)
+
+	return regexp.MustCompile(b.String())
+}
+
+// matchRule returns the first of rules that matches e, or nil.
+func matchRule(rules []rule, e Event) *rule {
+	var name, namespace string
+
+	switch {
+	case e.Job != nil:
+		name, namespace = e.Job.Name, e.Job.Namespace
+	case e.Dataset != nil:
+		namespace = e.Dataset.Namespace
+	}
+
+	for i := range rules {
+		r := &rules[i]
+
+		if r.job != nil && (e.Job == nil || !r.job.MatchString(name)) {
+			continue
+		}
+
+		if r.namespace != nil && !r.namespace.MatchString(namespace) {
+			continue
+		}
+
+		return r
+	}
+
+	return nil
+}
+
+// setParentNamespaces returns run with the namespaces of the jobs in its parent facet replaced like [Rule.SetNamespace]
+// replaces them in the events of those jobs, so that the facet keeps referring to them. run itself is left unchanged.
+func setParentNamespaces(rules []rule, run *Run) *Run {
+	if len(rules) == 0 || run == nil || run.Facets == nil || run.Facets.Parent == nil {
+		return run
+	}
+
+	parent := *run.Facets.Parent
+	parent.Job.Namespace = jobNamespace(rules, parent.Job)
+
+	if parent.Root != nil {
+		root := *parent.Root
+		root.Job.Namespace = jobNamespace(rules, root.Job)
+		parent.Root = &root
+	}
+
+	runFacets := *run.Facets
+	runFacets.Parent = &parent
+
+	r := *run
+	r.Facets = &runFacets
+
+	return &r
+}
+
+// jobNamespace returns the namespace that events of job are emitted with.
+func jobNamespace(rules []rule, job facets.Job) string {
+	r := matchRule(rules, Event{Job: &Job{Name: job.Name, Namespace: job.Namespace}})
+	if r == nil || r.SetNamespace == "" {
+		return job.Namespace
+	}
+
+	return r.SetNamespace
+}
@@ -0,0 +1,213 @@
+package openlineage_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/ThijsKoot/openlineage-go/pkg/lineagetest"
+	"github.com/ThijsKoot/openlineage-go/pkg/oltest"
+	"github.com/ThijsKoot/openlineage-go/pkg/transport"
+	"github.com/go-test/deep"
+	"github.com/google/uuid"
+)
+
+func Test_Client_Rules(t *testing.T) {
+	rules := []openlineage.Rule{
+		{Job: "scratch_audit"},
+		{Job: "scratch_*", Disabled: true},
+		{Namespace: "pii.*", Transport: "internal"},
+		{Job: "nightly_export", SetNamespace: "reports"},
+		{Job: "pii_export", SetNamespace: "reports"},
+	}
+
+	tests := []struct {
+		name      string
+		event     func(c *openlineage.Client) openlineage.Emittable
+		fault     *lineagetest.Fault
+		want      []string
+		wantRoute string
+		wantNS    string
+	}{
+		{
+			name: "no-match",
+			event: func(c *openlineage.Client) openlineage.Emittable {
+				return c.NewRunEvent(openlineage.EventTypeStart, uuid.New(), "load")
+			},
+			want:      []string{"emitted http START"},
+			wantRoute: "default",
+			wantNS:    "ns",
+		},
+		{
+			name: "disabled",
+			event: func(c *openlineage.Client) openlineage.Emittable {
+				return c.NewRunEvent(openlineage.EventTypeStart, uuid.New(), "scratch_1")
+			},
+			want: []string{"dropped rule"},
+		},
+		{
+			name: "exempted",
+			event: func(c *openlineage.Client) openlineage.Emittable {
+				return c.NewJobEvent("scratch_audit")
+			},
+			want:      []string{"emitted http JOB"},
+			wantRoute: "default",
+			wantNS:    "ns",
+		},
+		{
+			name: "routed",
+			event: func(c *openlineage.Client) openlineage.Emittable {
+				e := c.NewRunEvent(openlineage.EventTypeComplete, uuid.New(), "load")
+				e.Job.Namespace = "pii.customers"
+				return e
+			},
+			want:      []string{"emitted internal COMPLETE"},
+			wantRoute: "internal",
+			wantNS:    "pii.customers",
+		},
+		{
+			name: "routed-retried",
+			event: func(c *openlineage.Client) openlineage.Emittable {
+				e := c.NewRunEvent(openlineage.EventTypeComplete, uuid.New(), "load")
+				e.Job.Namespace = "pii.customers"
+				return e
+			},
+			fault:     &lineagetest.Fault{StatusCode: http.StatusServiceUnavailable, Times: 1},
+			want:      []string{"retried internal", "emitted internal COMPLETE"},
+			wantRoute: "internal",
+			wantNS:    "pii.customers",
+		},
+		{
+			name: "first-match-only",
+			event: func(c *openlineage.Client) openlineage.Emittable {
+				e := c.NewRunEvent(openlineage.EventTypeStart, uuid.New(), "pii_export")
+				e.Job.Namespace = "pii.customers"
+				return e
+			},
+			want:      []string{"emitted internal START"},
+			wantRoute: "internal",
+			wantNS:    "pii.customers",
+		},
+		{
+			name: "routed-dataset",
+			event: func(c *openlineage.Client) openlineage.Emittable {
+				e := c.NewDatasetEvent("scratch_table", "pii.db")
+				return &e
+			},
+			want:      []string{"emitted internal DATASET"},
+			wantRoute: "internal",
+		},
+		{
+			name: "set-namespace",
+			event: func(c *openlineage.Client) openlineage.Emittable {
+				return c.NewRunEvent(openlineage.EventTypeStart, uuid.New(), "nightly_export")
+			},
+			want:      []string{"emitted http START"},
+			wantRoute: "default",
+			wantNS:    "reports",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			servers := map[string]*lineagetest.Server{
+				"default":  lineagetest.NewServer(),
+				"internal": lineagetest.NewServer(),
+			}
+
+			for _, srv := range servers {
+				defer srv.Close()
+			}
+
+			if tt.fault != nil {
+				servers[tt.wantRoute].InjectFault(*tt.fault)
+			}
+
+			rec := &fakeRecorder{}
+			client, err := openlineage.NewClient(openlineage.ClientConfig{
+				Namespace: "ns",
+				Transport: transport.Config{
+					Type: transport.TransportTypeHTTP,
+					HTTP: &transport.HTTPConfig{URL: servers["default"].URL},
+				},
+				Transports: map[string]transport.Config{
+					"internal": {
+						Type: transport.TransportTypeHTTP,
+						HTTP: &transport.HTTPConfig{URL: servers["internal"].URL},
+					},
+				},
+				Rules: rules,
+			}, openlineage.WithMetrics(rec))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			event := tt.event(client)
+			before := event.AsEmittable()
+			beforeJob := before.Job
+			if beforeJob != nil {
+				job := *beforeJob
+				beforeJob = &job
+			}
+
+			if err := client.Emit(context.Background(), event); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := deep.Equal(tt.want, rec.measurements); diff != nil {
+				t.Errorf("differences found:\n%s", diff)
+			}
+
+			for name, srv := range servers {
+				events := srv.Events()
+
+				if name != tt.wantRoute {
+					if len(events) != 0 {
+						t.Errorf("%s received %d events, want none", name, len(events))
+					}
+
+					continue
+				}
+
+				if len(events) != 1 {
+					t.Fatalf("%s received %d events, want 1", name, len(events))
+				}
+
+				if events[0].Job != nil && events[0].Job.Namespace != tt.wantNS {
+					t.Errorf("job namespace = %s, want %s", events[0].Job.Namespace, tt.wantNS)
+				}
+			}
+
+			// rules must not change the caller's event
+			if diff := deep.Equal(beforeJob, event.AsEmittable().Job); diff != nil {
+				t.Errorf("event was changed:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Client_Rules_WithoutRoutes(t *testing.T) {
+	rec := oltest.NewRecorder()
+	client := openlineage.NewClientWithTransport(openlineage.ClientConfig{
+		Namespace: "ns",
+		Rules: []openlineage.Rule{
+			{Namespace: "pii.*", Transport: "internal"},
+		},
+	}, rec)
+
+	routed := client.NewRunEvent(openlineage.EventTypeStart, uuid.New(), "load")
+	routed.Job.Namespace = "pii.customers"
+
+	if err := client.Emit(context.Background(), routed); err == nil {
+		t.Error("expected an error for an event routed to a transport the client does not have")
+	}
+
+	if err := client.Emit(context.Background(), client.NewRunEvent(openlineage.EventTypeStart, uuid.New(), "load")); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(rec.Events()); n != 1 {
+		t.Errorf("recorder received %d events, want 1", n)
+	}
+}
@@ -2,7 +2,6 @@ package openlineage
 
 import (
 	"context"
-	"time"
 
 	"github.com/ThijsKoot/openlineage-go/pkg/facets"
 	"github.com/google/uuid"
@@ -47,19 +46,30 @@ func (e *RunEvent) Emit() {
 	_ = DefaultClient.Emit(context.Background(), e)
 }
 
-// NewNamespacedRunEvent creates a new [RunEvent] with EventTime set to [time.Now].
+// NewNamespacedRunEvent calls [Client.NewNamespacedRunEvent] on [DefaultClient].
 func NewNamespacedRunEvent(
 	eventType EventType,
 	runID uuid.UUID,
 	jobName string,
 	jobNamespace string,
+) *RunEvent {
+	return DefaultClient.NewNamespacedRunEvent(eventType, runID, jobName, jobNamespace)
+}
+
+// NewRunEvent calls [NewNamespacedRunEvent] with [DefaultNamespace].
+func NewRunEvent(eventType EventType, runID uuid.UUID, jobName string) *RunEvent {
+	return NewNamespacedRunEvent(eventType, runID, jobName, DefaultNamespace)
+}
+
+// NewNamespacedRunEvent creates a new [RunEvent] with EventTime set to the current time of the Client's [Clock].
+func (olc *Client) NewNamespacedRunEvent(
+	eventType EventType,
+	runID uuid.UUID,
+	jobName string,
+	jobNamespace string,
 ) *RunEvent {
 	return &RunEvent{
-		BaseEvent: BaseEvent{
-			Producer:  producer,
-			SchemaURL: schemaURL,
-			EventTime: time.Now().Format(time.RFC3339),
-		},
+		BaseEvent: olc.newBaseEvent(),
 		Run: Run{
 			RunID: runID.String(),
 		},
@@ -71,9 +81,9 @@ func NewNamespacedRunEvent(
 	}
 }
 
-// NewRunEvent calls [NewNamespacedRunEvent] with [DefaultNamespace].
-func NewRunEvent(eventType EventType, runID uuid.UUID, jobName string) *RunEvent {
-	return NewNamespacedRunEvent(eventType, runID, jobName, DefaultNamespace)
+// NewRunEvent calls [Client.NewNamespacedRunEvent] with the Client's namespace.
+func (olc *Client) NewRunEvent(eventType EventType, runID uuid.UUID, jobName string) *RunEvent {
+	return olc.NewNamespacedRunEvent(eventType, runID, jobName, olc.Namespace)
 }
 
 // WithRunFacets sets the supplied [facets.RunFacet] for this RunEvent.
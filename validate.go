@@ -0,0 +1,127 @@
+package openlineage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FieldError describes a problem with a single field.
+type FieldError struct {
+	// Path to the field, e.g. "run.runId"
+	Path    string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// FieldErrors is a list of problems found during validation.
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func (e *FieldErrors) add(path, format string, args ...any) {
+	*e = append(*e, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// ValidateEvent checks that event contains the fields required by the OpenLineage specification
+// for its kind: a run event, job event or dataset event.
+// All problems are reported at once as [FieldErrors].
+//
+// This is a structural check of the required fields, timestamps, UUIDs and event types.
+// It does not validate against the JSON schema of the specification, so facets are not checked; see package schema for that.
+func ValidateEvent(event Event) error {
+	var errs FieldErrors
+
+	if event.EventTime == "" {
+		errs.add("eventTime", "is required")
+	} else if _, err := time.Parse(time.RFC3339Nano, event.EventTime); err != nil {
+		errs.add("eventTime", "is not a valid RFC 3339 timestamp: %q", event.EventTime)
+	}
+
+	if event.Producer == "" {
+		errs.add("producer", "is required")
+	}
+
+	if event.SchemaURL == "" {
+		errs.add("schemaURL", "is required")
+	}
+
+	switch {
+	case event.Run != nil || event.EventType != nil:
+		if event.Run == nil {
+			errs.add("run", "is required")
+		} else if _, err := uuid.Parse(event.Run.RunID); err != nil {
+			errs.add("run.runId", "is not a valid UUID: %q", event.Run.RunID)
+		}
+
+		if event.EventType != nil && !validEventType(*event.EventType) {
+			errs.add("eventType", "is not a valid event type: %q", *event.EventType)
+		}
+
+		validateJob(&errs, event.Job)
+		validateDatasets(&errs, event.Inputs, event.Outputs)
+	case event.Dataset != nil:
+		validateName(&errs, "dataset", event.Dataset.Name, event.Dataset.Namespace)
+	case event.Job != nil:
+		validateJob(&errs, event.Job)
+		validateDatasets(&errs, event.Inputs, event.Outputs)
+	default:
+		errs.add("", "event contains neither a run, a job nor a dataset")
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func validEventType(eventType EventType) bool {
+	switch eventType {
+	case EventTypeStart, EventTypeRunning, EventTypeComplete, EventTypeFail, EventTypeAbort, EventTypeOther:
+		return true
+	}
+
+	return false
+}
+
+func validateJob(errs *FieldErrors, job *Job) {
+	if job == nil {
+		errs.add("job", "is required")
+		return
+	}
+
+	validateName(errs, "job", job.Name, job.Namespace)
+}
+
+func validateDatasets(errs *FieldErrors, inputs []InputElement, outputs []OutputElement) {
+	for i, in := range inputs {
+		validateName(errs, fmt.Sprintf("inputs[%d]", i), in.Name, in.Namespace)
+	}
+
+	for i, out := range outputs {
+		validateName(errs, fmt.Sprintf("outputs[%d]", i), out.Name, out.Namespace)
+	}
+}
+
+func validateName(errs *FieldErrors, path, name, namespace string) {
+	if name == "" {
+		errs.add(path+".name", "is required")
+	}
+
+	if namespace == "" {
+		errs.add(path+".namespace", "is required")
+	}
+}
@@ -0,0 +1,87 @@
+package openlineage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ThijsKoot/openlineage-go"
+	"github.com/google/uuid"
+)
+
+func Test_ValidateEvent(t *testing.T) {
+	runID := uuid.MustParse("0190e8b5-5f4e-7a4c-a1a4-16d4ba5c1b8c")
+	invalidType := openlineage.EventType("BOGUS")
+
+	cases := []struct {
+		name      string
+		event     openlineage.Event
+		wantPaths []string
+	}{
+		{
+			name:  "run-event",
+			event: openlineage.NewRunEvent(openlineage.EventTypeStart, runID, "job").AsEmittable(),
+		},
+		{
+			name:  "job-event",
+			event: openlineage.NewJobEvent("job").AsEmittable(),
+		},
+		{
+			name: "dataset-event",
+			event: func() openlineage.Event {
+				e := openlineage.NewDatasetEvent("table", "ns")
+				return e.AsEmittable()
+			}(),
+		},
+		{
+			name:      "empty",
+			event:     openlineage.Event{},
+			wantPaths: []string{"eventTime", "producer", "schemaURL", ""},
+		},
+		{
+			name: "invalid-run-event",
+			event: openlineage.Event{
+				EventTime: "yesterday",
+				Producer:  "producer",
+				SchemaURL: "schema",
+				EventType: &invalidType,
+				Run:       &openlineage.Run{RunID: "123"},
+				Job:       &openlineage.Job{Name: "job"},
+				Inputs:    []openlineage.InputElement{{Namespace: "ns"}},
+			},
+			wantPaths: []string{"eventTime", "run.runId", "eventType", "job.namespace", "inputs[0].name"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := openlineage.ValidateEvent(tt.event)
+			if len(tt.wantPaths) == 0 {
+				if err != nil {
+					t.Fatalf("ValidateEvent() = %v", err)
+				}
+
+				return
+			}
+
+			var fieldErrs openlineage.FieldErrors
+			if !errors.As(err, &fieldErrs) {
+				t.Fatalf("ValidateEvent() = %v, want FieldErrors", err)
+			}
+
+			var paths []string
+			for _, fe := range fieldErrs {
+				paths = append(paths, fe.Path)
+			}
+
+			if len(paths) != len(tt.wantPaths) {
+				t.Fatalf("errors for paths %q, want %q", paths, tt.wantPaths)
+			}
+
+			for i := range paths {
+				if paths[i] != tt.wantPaths[i] {
+					t.Errorf("error %d for path %q, want %q", i, paths[i], tt.wantPaths[i])
+				}
+			}
+		})
+	}
+}